@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"flint-cli/internal/config"
+	"flint-cli/internal/credhelper"
+	"flint-cli/internal/utils"
+)
+
+var (
+	helperEnv   []string
+	helperUnset bool
+	helperTest  bool
+)
+
+var helperCmd = &cobra.Command{
+	Use:   "helper <pocketbase|nats> [-- command [args...]]",
+	Short: "Configure an external credential helper",
+	Long: `Configure a command that supplies credentials for the active context.
+
+Instead of reading the secrets stored in the context, flint runs the helper
+and reads a JSON document from its standard output. This lets existing vault
+or SSO tooling hand credentials to flint without storing them.
+
+The helper runs with FLINT_CREDENTIAL_SERVICE (pocketbase or nats) and
+FLINT_CREDENTIAL_CONTEXT set, plus any --env variables. Its stderr and stdin
+are connected to the terminal so it can prompt. It must print:
+
+  PocketBase:  {"token": "<session token>", "expires_at": "2025-01-02T15:04:05Z"}
+  NATS:        {"token": "..."}  or  {"username": "...", "password": "..."}
+               or  {"creds_file": "/path/to/user.creds"}
+               or  {"nkey_file": "/path/to/user.nk"}
+
+The helper runs when a command first needs the credentials, not on every
+invocation. Results are kept in memory only, never written to disk, so the
+cache lasts for a single flint process: every command that talks to
+PocketBase or NATS runs the helper at least once. expires_at is optional;
+when present, the result is reused within the process until shortly before
+it expires, otherwise the helper runs each time credentials are needed. A
+helper that should not prompt on every command has to keep its own cache.
+A PocketBase token rejected by the server is discarded and the helper is
+asked once more. FLINT_PB_TOKEN and FLINT_NATS_PASSWORD/FLINT_NATS_TOKEN
+take precedence over a helper.
+
+Run without a command to show the configured helper.
+
+Examples:
+  # Fetch the PocketBase token from a vault
+  flint auth helper pocketbase -- vault-flint token --role operator
+
+  # Pass settings to the helper
+  flint auth helper nats --env VAULT_ADDR=https://vault.internal -- vault-flint nats
+
+  # Check what the helper returns (bypasses the cache)
+  flint auth helper pocketbase --test
+
+  # Go back to stored credentials
+  flint auth helper nats --unset`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := validateActiveContext()
+		if err != nil {
+			return err
+		}
+
+		service, err := helperService(args[0])
+		if err != nil {
+			return err
+		}
+		command := args[1:]
+
+		if helperUnset && (len(command) > 0 || len(helperEnv) > 0) {
+			return fmt.Errorf("--unset cannot be combined with a command or --env")
+		}
+		if len(helperEnv) > 0 && len(command) == 0 {
+			return fmt.Errorf("--env requires a helper command")
+		}
+
+		env := make(map[string]string)
+		for _, pair := range helperEnv {
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok || key == "" {
+				return fmt.Errorf("invalid --env value '%s', expected KEY=VALUE", pair)
+			}
+			env[key] = value
+		}
+
+		cmd.SilenceUsage = true
+
+		green := color.New(color.FgGreen).SprintFunc()
+		cyan := color.New(color.FgCyan).SprintFunc()
+
+		if helperUnset || len(command) > 0 {
+			var helper *config.CredentialHelper
+			if len(command) > 0 {
+				helper = &config.CredentialHelper{Command: command[0], Args: command[1:]}
+				if len(env) > 0 {
+					helper.Env = env
+				}
+			}
+
+			updated, err := configManager.UpdateContext(ctx.Name, func(stored *config.Context) error {
+				if service == credhelper.ServicePocketBase {
+					stored.PocketBase.CredentialHelper = helper
+				} else {
+					stored.NATS.CredentialHelper = helper
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to save credential helper: %w", err)
+			}
+			ctx = updated
+
+			credhelper.Invalidate(ctx.Name, service)
+
+			if helper == nil {
+				fmt.Printf("%s Removed the %s credential helper from %s\n", green("✓"), service, cyan(ctx.Name))
+			} else {
+				fmt.Printf("%s Configured the %s credential helper for %s\n", green("✓"), service, cyan(ctx.Name))
+			}
+		}
+
+		helper := ctx.PocketBase.CredentialHelper
+		if service == credhelper.ServiceNATS {
+			helper = ctx.NATS.CredentialHelper
+		}
+
+		if helper == nil {
+			if !helperUnset {
+				fmt.Printf("No %s credential helper configured for %s\n", service, cyan(ctx.Name))
+			}
+			if helperTest {
+				return fmt.Errorf("nothing to test")
+			}
+			return nil
+		}
+
+		if !helperUnset && len(command) == 0 {
+			printCredentialHelper(helper)
+		}
+
+		if helperTest {
+			fmt.Printf("\nRunning helper...\n")
+			creds, err := credhelper.Run(ctx.Name, service, helper)
+			if err != nil {
+				return err
+			}
+			printHelperCredentials(service, creds)
+		}
+
+		return nil
+	},
+}
+
+// helperService maps the service argument to a credential helper service
+func helperService(arg string) (string, error) {
+	switch strings.ToLower(arg) {
+	case "pocketbase", "pb":
+		return credhelper.ServicePocketBase, nil
+	case "nats":
+		return credhelper.ServiceNATS, nil
+	}
+	return "", fmt.Errorf("invalid service '%s'. Valid services: pocketbase, nats", arg)
+}
+
+// printCredentialHelper prints a helper's configuration
+func printCredentialHelper(helper *config.CredentialHelper) {
+	fmt.Printf("Command: %s\n", strings.Join(append([]string{helper.Command}, helper.Args...), " "))
+	for key, value := range helper.Env {
+		fmt.Printf("Env:     %s=%s\n", key, value)
+	}
+}
+
+// printHelperCredentials summarizes helper output without revealing secrets
+func printHelperCredentials(service string, creds *credhelper.Credentials) {
+	green := color.New(color.FgGreen).SprintFunc()
+
+	fmt.Printf("%s Helper returned valid %s credentials\n", green("✓"), service)
+	if service == credhelper.ServiceNATS {
+		fmt.Printf("  Auth Method: %s\n", creds.NATSAuthMethod())
+	}
+	if creds.Username != "" {
+		fmt.Printf("  Username: %s\n", creds.Username)
+	}
+	if creds.Token != "" {
+		fmt.Printf("  Token: %s\n", truncateToken(creds.Token))
+	}
+	if creds.CredsFile != "" {
+		fmt.Printf("  Credentials File: %s\n", creds.CredsFile)
+	}
+	if creds.NKeyFile != "" {
+		fmt.Printf("  NKey Seed File: %s\n", creds.NKeyFile)
+	}
+	if creds.ExpiresAt != nil {
+		left := time.Until(*creds.ExpiresAt)
+		fmt.Printf("  Expires: in %s (%s)\n", utils.FormatDuration(int64(left.Seconds())),
+			creds.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	} else {
+		fmt.Printf("  Expires: not reported (the helper runs on every command)\n")
+	}
+}
+
+func init() {
+	helperCmd.Flags().StringArrayVar(&helperEnv, "env", nil,
+		"Environment variable for the helper, KEY=VALUE (repeatable)")
+	helperCmd.Flags().BoolVar(&helperUnset, "unset", false,
+		"Remove the credential helper")
+	helperCmd.Flags().BoolVar(&helperTest, "test", false,
+		"Run the helper and show what it returns")
+}
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"flint-cli/internal/config"
+	"flint-cli/internal/pocketbase"
+	"flint-cli/internal/utils"
+)
+
+var (
+	impersonateDuration time.Duration
+	impersonateName     string
+	impersonateSelect   bool
+)
+
+var impersonateCmd = &cobra.Command{
+	Use:   "impersonate <collection> <record_id>",
+	Short: "Act as another user or device (superusers only)",
+	Long: `Mint a short-lived token for a specific auth record using the PocketBase
+impersonate endpoint, so you can see exactly what that principal sees.
+
+The active context must be authenticated as a PocketBase superuser
+('flint auth pb --superuser'). The token is stored in a derived context named
+<context>-as-<record_id> (or --name), which is a copy of the active context
+with the impersonated session. The superuser's credential helper and its
+Authorization, Cookie and Proxy-Authorization headers are not copied. Run
+collections commands against it with --context, or --select it. Impersonation tokens cannot be refreshed; run the
+command again to mint a new one.
+
+Examples:
+  # Act as a user for 30 minutes
+  flint auth impersonate users u_abc123def456789 --duration 30m
+
+  # See what an edge device sees
+  flint auth impersonate edges e_abc123def456789
+  flint --context production-as-e_abc123def456789 collections things list
+
+  # Impersonate and switch to the derived context
+  flint auth impersonate users u_abc123def456789 --name support-session --select`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := validateActiveContext()
+		if err != nil {
+			return err
+		}
+
+		collection, recordID := args[0], args[1]
+		if err := config.ValidateAuthCollection(collection); err != nil {
+			return err
+		}
+
+		if ctx.PocketBase.AuthCollection != config.AuthCollectionSuperusers || ctx.PocketBase.AuthToken == "" {
+			return fmt.Errorf("impersonation requires a superuser session. Run 'flint auth pb --superuser' first")
+		}
+		if !pocketbase.IsAuthValid(ctx) {
+			return fmt.Errorf("superuser session has expired. Run 'flint auth pb --superuser' to re-authenticate")
+		}
+		if impersonateDuration < 0 {
+			return fmt.Errorf("--duration must be positive")
+		}
+
+		derivedName := impersonateName
+		if derivedName == "" {
+			derivedName = fmt.Sprintf("%s-as-%s", ctx.Name, recordID)
+		}
+		if err := utils.ValidateContextName(derivedName); err != nil {
+			return fmt.Errorf("invalid derived context name '%s': %w (use --name)", derivedName, err)
+		}
+		if derivedName == ctx.Name {
+			return fmt.Errorf("derived context name must differ from the active context")
+		}
+
+		// Only replace contexts that were created by a previous impersonation
+		if configManager.ContextExists(derivedName) {
+			existing, err := configManager.LoadContext(derivedName)
+			if err != nil {
+				return err
+			}
+			if existing.PocketBase.ImpersonatedBy == "" {
+				return fmt.Errorf("context '%s' already exists and is not an impersonation context. Use --name to pick another name",
+					derivedName)
+			}
+		}
+
+		cmd.SilenceUsage = true
+
+		resolved := *ctx
+		resolved.PocketBase = configManager.ResolvePocketBaseConfig(ctx)
+		client := pocketbase.NewClientFromContext(&resolved)
+		utils.PrintInfo(fmt.Sprintf("Impersonating %s/%s...", collection, recordID))
+		authResp, err := client.Impersonate(collection, recordID, impersonateDuration)
+		if err != nil {
+			return authFailure(err)
+		}
+
+		if configManager.ContextExists(derivedName) {
+			if err := configManager.DeleteContext(derivedName); err != nil {
+				return fmt.Errorf("failed to replace context '%s': %w", derivedName, err)
+			}
+		}
+
+		derived, err := configManager.CloneContext(ctx.Name, derivedName, func(derived *config.Context) {
+			derived.PocketBase.AuthCollection = collection
+			derived.PocketBase.ImpersonatedBy = ctx.Name
+			// The derived session must never fall back to the superuser's credentials
+			derived.PocketBase.CredentialHelper = nil
+			derived.PocketBase.Headers = withoutIdentityHeaders(derived.PocketBase.Headers)
+			pocketbase.UpdateAuthContextFromResponse(derived, authResp, "")
+			if orgID, ok := authResp.Record["current_organization_id"].(string); ok && orgID != "" {
+				derived.PocketBase.OrganizationID = orgID
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create context '%s': %w", derivedName, err)
+		}
+
+		if impersonateSelect {
+			if err := configManager.SetActiveContext(derivedName); err != nil {
+				return fmt.Errorf("failed to select context '%s': %w", derivedName, err)
+			}
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		cyan := color.New(color.FgCyan).SprintFunc()
+		yellow := color.New(color.FgYellow).SprintFunc()
+
+		fmt.Printf("\n%s Impersonating %s/%s\n", green("✓"), collection, recordID)
+		fmt.Printf("\nImpersonation Details:\n")
+		fmt.Printf("  Collection: %s\n", pocketbase.GetCollectionDisplayName(collection))
+		if identity := recordIdentity(authResp.Record); identity != "" {
+			fmt.Printf("  Identity: %s\n", identity)
+		}
+		fmt.Printf("  Context: %s\n", cyan(derivedName))
+		if derived.PocketBase.OrganizationID != "" {
+			fmt.Printf("  Organization: %s\n", derived.PocketBase.OrganizationID)
+		}
+		if derived.PocketBase.AuthExpires != nil {
+			fmt.Printf("  Expires: %s\n", yellow(derived.PocketBase.AuthExpires.Local().Format("2006-01-02 15:04:05")))
+		}
+
+		fmt.Printf("\nNext steps:\n")
+		if impersonateSelect {
+			fmt.Printf("  List records as this principal: %s\n", cyan("flint collections edges list"))
+			fmt.Printf("  Switch back: %s\n", cyan(fmt.Sprintf("flint context select %s", ctx.Name)))
+		} else {
+			fmt.Printf("  List records as this principal: %s\n",
+				cyan(fmt.Sprintf("flint --context %s collections edges list", derivedName)))
+		}
+		fmt.Printf("  Remove when done: %s\n", cyan(fmt.Sprintf("flint context delete %s", derivedName)))
+
+		return nil
+	},
+}
+
+// identityHeaders identify the caller rather than route the request, so they
+// are not copied to an impersonated context
+var identityHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
+// withoutIdentityHeaders returns headers without the identity headers. Other
+// headers, such as gateway keys, are kept so the server stays reachable.
+func withoutIdentityHeaders(headers map[string]string) map[string]string {
+	kept := make(map[string]string, len(headers))
+	for key, value := range headers {
+		if !isIdentityHeader(key) {
+			kept[key] = value
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
+
+// isIdentityHeader reports whether key is one of identityHeaders, in any case
+func isIdentityHeader(key string) bool {
+	for _, identity := range identityHeaders {
+		if http.CanonicalHeaderKey(key) == identity {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	impersonateCmd.Flags().DurationVar(&impersonateDuration, "duration", 0,
+		"Token lifetime, e.g. 15m or 1h (default: the collection's token duration)")
+	impersonateCmd.Flags().StringVar(&impersonateName, "name", "",
+		"Name of the derived context (default: <context>-as-<record_id>)")
+	impersonateCmd.Flags().BoolVar(&impersonateSelect, "select", false,
+		"Make the derived context the active context")
+}
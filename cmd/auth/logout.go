@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"flint-cli/internal/config"
+	"flint-cli/internal/pocketbase"
+	"flint-cli/internal/utils"
+)
+
+var (
+	logoutOutputFormat string
+	logoutAllContexts  bool
+	logoutNATS         bool
+)
+
+// LogoutResult describes the credentials removed from one context
+type LogoutResult struct {
+	Context           string `json:"context" yaml:"context"`
+	PocketBaseCleared bool   `json:"pocketbase_cleared" yaml:"pocketbase_cleared"`
+	NATSCleared       bool   `json:"nats_cleared" yaml:"nats_cleared"`
+	CredsFile         string `json:"creds_file,omitempty" yaml:"creds_file,omitempty"`
+	Error             string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+var logoutCmd = &cobra.Command{
+	Use:   "logout [name]",
+	Short: "Remove stored session credentials",
+	Long: `Remove the stored PocketBase session from a context.
+
+The auth token, auth record and token expiry are wiped; the PocketBase URL,
+auth collection and organization are kept so 'flint auth pb' can log in again.
+With --nats the NATS password and token are wiped as well. NATS credentials
+and NKey seed files are never deleted; remove them yourself if they should go too.
+
+Examples:
+  flint auth logout
+  flint auth logout production
+  flint auth logout --nats
+  flint auth logout --all-contexts
+  flint auth logout --all-contexts --nats -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateConfigManager(); err != nil {
+			return err
+		}
+
+		format, err := authOutputFormat(logoutOutputFormat)
+		if err != nil {
+			return err
+		}
+		if logoutAllContexts && len(args) > 0 {
+			return fmt.Errorf("--all-contexts cannot be combined with a context name")
+		}
+
+		var names []string
+		switch {
+		case logoutAllContexts:
+			names, err = configManager.ListContexts()
+			if err != nil {
+				return err
+			}
+			if len(names) == 0 {
+				return fmt.Errorf("no contexts found")
+			}
+		case len(args) == 1:
+			if !configManager.ContextExists(args[0]) {
+				return fmt.Errorf("context '%s' not found", args[0])
+			}
+			names = []string{args[0]}
+		default:
+			activeName, source, err := configManager.GetActiveContextName()
+			if err != nil || activeName == "" {
+				return fmt.Errorf("no active context set. Use 'flint context select <name>' to set one")
+			}
+			if !configManager.ContextExists(activeName) {
+				return fmt.Errorf("context '%s' (from %s) not found", activeName, source)
+			}
+			names = []string{activeName}
+		}
+
+		cmd.SilenceUsage = true
+
+		var results []LogoutResult
+		failed := 0
+		for _, name := range names {
+			result := logoutContext(name, logoutNATS)
+			if result.Error != "" {
+				failed++
+			}
+			results = append(results, result)
+		}
+
+		if format != config.OutputFormatTable {
+			if err := utils.OutputData(results, format); err != nil {
+				return err
+			}
+		} else {
+			printLogoutResults(results)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("failed to log out of %d context(s)", failed)
+		}
+		return nil
+	},
+}
+
+// logoutContext wipes the session credentials of a context
+func logoutContext(name string, includeNATS bool) LogoutResult {
+	result := LogoutResult{Context: name}
+
+	_, err := configManager.UpdateContext(name, func(ctx *config.Context) error {
+		pb := &ctx.PocketBase
+		result.PocketBaseCleared = pb.AuthToken != "" || len(pb.AuthRecord) > 0 || pb.AuthExpires != nil
+		pb.AuthToken = ""
+		pb.AuthRecord = nil
+		pb.AuthExpires = nil
+
+		if includeNATS {
+			result.NATSCleared = ctx.NATS.Password != "" || ctx.NATS.Token != ""
+			ctx.NATS.Password = ""
+			ctx.NATS.Token = ""
+			switch ctx.NATS.AuthMethod {
+			case config.NATSAuthCreds:
+				result.CredsFile = ctx.NATS.CredsFile
+			case config.NATSAuthNKey:
+				result.CredsFile = ctx.NATS.NKeyFile
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	// Schemas cached for this session must not outlive it
+	if err := pocketbase.InvalidateSchemaCache(name); err != nil {
+		utils.PrintWarning(err.Error())
+	}
+
+	return result
+}
+
+// printLogoutResults prints what was removed from each context
+func printLogoutResults(results []LogoutResult) {
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	for _, result := range results {
+		switch {
+		case result.Error != "":
+			fmt.Printf("%s %s: %s\n", red("✗"), cyan(result.Context), result.Error)
+		case result.PocketBaseCleared || result.NATSCleared:
+			fmt.Printf("%s Logged out of %s\n", green("✓"), cyan(result.Context))
+			if result.NATSCleared {
+				fmt.Printf("  NATS password/token removed\n")
+			}
+		default:
+			fmt.Printf("  %s: not logged in\n", cyan(result.Context))
+		}
+		if result.Error == "" && result.CredsFile != "" {
+			fmt.Printf("  NATS credentials file kept: %s\n", result.CredsFile)
+		}
+	}
+}
+
+func init() {
+	logoutCmd.Flags().StringVarP(&logoutOutputFormat, "output", "o", "table",
+		"Output format (table|json|yaml)")
+	logoutCmd.Flags().BoolVar(&logoutAllContexts, "all-contexts", false,
+		"Log out of every context")
+	logoutCmd.Flags().BoolVar(&logoutNATS, "nats", false,
+		"Also remove the stored NATS password and token")
+}
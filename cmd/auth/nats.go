@@ -2,17 +2,21 @@ package auth
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/nats-io/nkeys"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 	"flint-cli/internal/config"
 	natsClient "flint-cli/internal/nats"
+	"flint-cli/internal/pocketbase"
 	"flint-cli/internal/utils"
 )
 
@@ -21,10 +25,30 @@ var (
 	natsPassword   string
 	natsToken      string
 	natsCredsFile  string
+	natsNKeyFile   string
 	natsAuthMethod string
+	natsFromClient string
 	natsTestConn   bool
+
+	natsPasswordStdin bool
+	natsPasswordFile  string
+	natsTokenStdin    bool
+	natsTokenFile     string
+
+	natsTLSCAFile     string
+	natsTLSCertFile   string
+	natsTLSKeyFile    string
+	natsTLSServerName string
+	natsTLSMinVersion string
 )
 
+// natsCredentialFlags are the flags that supply NATS credentials
+var natsCredentialFlags = []string{"method", "username", "password", "password-stdin", "password-file",
+	"token", "token-stdin", "token-file", "creds-file", "nkey-file"}
+
+// natsTLSFlags are the flags that change the NATS TLS settings
+var natsTLSFlags = []string{"tls-ca", "tls-cert", "tls-key", "tls-server-name", "tls-min-version"}
+
 var natsCmd = &cobra.Command{
 	Use:   "nats",
 	Short: "Configure NATS authentication",
@@ -34,6 +58,7 @@ NATS supports multiple authentication methods:
   user_pass  Username and password authentication
   token      JWT token authentication
   creds      JWT credentials file authentication (recommended)
+  nkey       NKey seed file authentication (no JWT)
 
 The authentication method is configured in your context. This command updates
 the credentials for the configured method, or allows you to change the method.
@@ -53,6 +78,34 @@ creds:
   Uses a .creds file containing JWT and NKey for secure authentication.
   The file path is stored in the context, with support for relative paths.
 
+nkey:
+  Bare NKey authentication for accounts configured with a user public key
+  instead of a JWT. Uses a file containing the user seed (SU...). Like creds
+  files, seeds inside the context directory (e.g. ./nats.nk) are stored as
+  relative paths. The public key is shown so it can be added to the server.
+
+Client records:
+  --from-client fetches a record from the PocketBase clients collection, by
+  record ID or NATS username, using the current PocketBase session. Its
+  credentials file is written to nats.creds in the context directory (mode
+  0600) and the creds method is selected; bearer-token clients use the token
+  method with the client JWT instead. The connection is then tested, as with
+  --test.
+
+TLS:
+  --tls-ca, --tls-cert and --tls-key configure a private CA bundle and a client
+  certificate for mutual TLS; --tls-server-name overrides the name verified in
+  the server certificate and --tls-min-version sets the lowest accepted TLS
+  version (1.2 by default). Any of them enables TLS, and an empty value clears
+  the setting. When only TLS flags are given, the stored credentials are kept.
+
+Non-interactive use (CI):
+  Secrets passed as flags end up in shell history. Use --password-stdin or
+  --password-file (--token-stdin or --token-file for tokens) instead. When
+  FLINT_NATS_PASSWORD or FLINT_NATS_TOKEN is set, it is used for connections
+  in place of the stored secret and is never written to the context; in that
+  case this command only records the method (and username) without prompting.
+
 Examples:
   # Configure username/password authentication
   flint auth nats --method user_pass --username client001 --password secret
@@ -63,9 +116,22 @@ Examples:
   # Configure credentials file authentication
   flint auth nats --method creds --creds-file /path/to/client.creds
 
+  # Configure NKey seed authentication
+  flint auth nats --method nkey --nkey-file ./nats.nk --test
+
+  # Use the credentials of a PocketBase client record
+  flint auth nats --from-client edge_gateway_01
+
+  # Trust a private CA and present a client certificate
+  flint auth nats --tls-ca ./ca.pem --tls-cert ./client.pem --tls-key ./client-key.pem --test
+
   # Interactive configuration (prompts for credentials)
   flint auth nats
 
+  # Read secrets without exposing them on the command line
+  echo "$NATS_PASSWORD" | flint auth nats --method user_pass --username client001 --password-stdin
+  flint auth nats --method token --token-file /run/secrets/nats-token
+
   # Test connection after configuration
   flint auth nats --test`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -80,33 +146,60 @@ Examples:
 			authMethod = ctx.NATS.AuthMethod
 		}
 
-		// Validate or prompt for auth method if needed
-		if authMethod == "" {
-			authMethod, err = promptForAuthMethod()
-			if err != nil {
-				return fmt.Errorf("failed to get authentication method: %w", err)
+		// TLS settings can be changed on their own, keeping the stored credentials
+		tlsChanged := anyFlagChanged(cmd, natsTLSFlags)
+		tlsOnly := tlsChanged && natsFromClient == "" && !anyFlagChanged(cmd, natsCredentialFlags) &&
+			ctx.NATS.AuthMethod != ""
+		if tlsChanged {
+			if err := configureNATSTLS(cmd, ctx); err != nil {
+				return err
 			}
 		}
 
-		// Validate auth method
-		if err := utils.ValidateNATSAuthMethod(authMethod); err != nil {
-			return err
-		}
+		if natsFromClient != "" {
+			// The client record decides the method and supplies the credentials
+			for _, name := range natsCredentialFlags {
+				if cmd.Flags().Changed(name) {
+					return fmt.Errorf("--from-client cannot be combined with --%s", name)
+				}
+			}
 
-		// Configure credentials based on method
-		switch authMethod {
-		case config.NATSAuthUserPass:
-			err = configureUserPassAuth(ctx)
-		case config.NATSAuthToken:
-			err = configureTokenAuth(ctx)
-		case config.NATSAuthCreds:
-			err = configureCredsAuth(ctx)
-		default:
-			return fmt.Errorf("unsupported authentication method: %s", authMethod)
-		}
+			cmd.SilenceUsage = true
+			authMethod, err = configureFromClientAuth(ctx, natsFromClient)
+			if err != nil {
+				return err
+			}
+		} else if !tlsOnly {
+			// Validate or prompt for auth method if needed
+			if authMethod == "" {
+				authMethod, err = promptForAuthMethod()
+				if err != nil {
+					return fmt.Errorf("failed to get authentication method: %w", err)
+				}
+			}
 
-		if err != nil {
-			return err
+			// Validate auth method
+			if err := utils.ValidateNATSAuthMethod(authMethod); err != nil {
+				return err
+			}
+
+			// Configure credentials based on method
+			switch authMethod {
+			case config.NATSAuthUserPass:
+				err = configureUserPassAuth(ctx)
+			case config.NATSAuthToken:
+				err = configureTokenAuth(ctx)
+			case config.NATSAuthCreds:
+				err = configureCredsAuth(ctx)
+			case config.NATSAuthNKey:
+				err = configureNKeyAuth(ctx)
+			default:
+				return fmt.Errorf("unsupported authentication method: %s", authMethod)
+			}
+
+			if err != nil {
+				return err
+			}
 		}
 
 		// Update auth method in context
@@ -121,7 +214,11 @@ Examples:
 		green := color.New(color.FgGreen).SprintFunc()
 		cyan := color.New(color.FgCyan).SprintFunc()
 		
-		fmt.Printf("\n%s NATS authentication configured successfully!\n", green("✓"))
+		if tlsOnly {
+			fmt.Printf("\n%s NATS TLS settings updated!\n", green("✓"))
+		} else {
+			fmt.Printf("\n%s NATS authentication configured successfully!\n", green("✓"))
+		}
 		fmt.Printf("\nAuthentication Details:\n")
 		fmt.Printf("  Method: %s\n", authMethod)
 		fmt.Printf("  Context: %s\n", cyan(ctx.Name))
@@ -129,14 +226,28 @@ Examples:
 		switch authMethod {
 		case config.NATSAuthUserPass:
 			fmt.Printf("  Username: %s\n", ctx.NATS.Username)
+			if ctx.NATS.Password == "" {
+				fmt.Printf("  Password: from %s (not stored)\n", natsClient.EnvPassword)
+			}
 		case config.NATSAuthToken:
-			fmt.Printf("  Token: %s\n", truncateToken(ctx.NATS.Token))
+			if ctx.NATS.Token == "" {
+				fmt.Printf("  Token: from %s (not stored)\n", natsClient.EnvToken)
+			} else {
+				fmt.Printf("  Token: %s\n", truncateToken(ctx.NATS.Token))
+			}
 		case config.NATSAuthCreds:
 			fmt.Printf("  Credentials File: %s\n", ctx.NATS.CredsFile)
+		case config.NATSAuthNKey:
+			fmt.Printf("  NKey Seed File: %s\n", ctx.NATS.NKeyFile)
+			seedPath := configManager.ResolveContextPath(ctx.Name, ctx.NATS.NKeyFile)
+			if publicKey, err := natsClient.NKeyPublicKey(seedPath); err == nil {
+				fmt.Printf("  Public Key: %s\n", publicKey)
+			}
 		}
+		printNATSTLSSettings(ctx)
 
-		// Test connection if requested
-		if natsTestConn {
+		// Test connection if requested; credentials taken from a client record are always tested
+		if natsTestConn || natsFromClient != "" {
 			fmt.Printf("\nTesting NATS connection...\n")
 			if err := testNATSConnection(ctx); err != nil {
 				utils.PrintWarning(fmt.Sprintf("Connection test failed: %v", err))
@@ -159,15 +270,37 @@ Examples:
 
 func init() {
 	natsCmd.Flags().StringVar(&natsAuthMethod, "method", "", 
-		"Authentication method (user_pass|token|creds)")
+		"Authentication method (user_pass|token|creds|nkey)")
 	natsCmd.Flags().StringVarP(&natsUsername, "username", "u", "", 
 		"Username for user_pass authentication")
 	natsCmd.Flags().StringVarP(&natsPassword, "password", "p", "", 
 		"Password for user_pass authentication (will prompt if not provided)")
+	natsCmd.Flags().BoolVar(&natsPasswordStdin, "password-stdin", false,
+		"Read the user_pass password from standard input")
+	natsCmd.Flags().StringVar(&natsPasswordFile, "password-file", "",
+		"Read the user_pass password from a file")
 	natsCmd.Flags().StringVar(&natsToken, "token", "", 
 		"JWT token for token authentication")
+	natsCmd.Flags().BoolVar(&natsTokenStdin, "token-stdin", false,
+		"Read the token from standard input")
+	natsCmd.Flags().StringVar(&natsTokenFile, "token-file", "",
+		"Read the token from a file")
 	natsCmd.Flags().StringVar(&natsCredsFile, "creds-file", "", 
 		"Path to JWT credentials file for creds authentication")
+	natsCmd.Flags().StringVar(&natsNKeyFile, "nkey-file", "",
+		"Path to NKey user seed file for nkey authentication")
+	natsCmd.Flags().StringVar(&natsFromClient, "from-client", "",
+		"Use the credentials of a PocketBase client record (ID or NATS username)")
+	natsCmd.Flags().StringVar(&natsTLSCAFile, "tls-ca", "",
+		"PEM CA bundle used to verify the NATS servers")
+	natsCmd.Flags().StringVar(&natsTLSCertFile, "tls-cert", "",
+		"Client certificate for mutual TLS")
+	natsCmd.Flags().StringVar(&natsTLSKeyFile, "tls-key", "",
+		"Client private key for mutual TLS")
+	natsCmd.Flags().StringVar(&natsTLSServerName, "tls-server-name", "",
+		"Server name to verify in the NATS server certificate")
+	natsCmd.Flags().StringVar(&natsTLSMinVersion, "tls-min-version", "",
+		"Minimum TLS version (1.0|1.1|1.2|1.3)")
 	natsCmd.Flags().BoolVar(&natsTestConn, "test", false, 
 		"Test NATS connection after configuration")
 }
@@ -178,7 +311,8 @@ func promptForAuthMethod() (string, error) {
 	fmt.Printf("  1. user_pass - Username and password\n")
 	fmt.Printf("  2. token - JWT token\n")
 	fmt.Printf("  3. creds - JWT credentials file (recommended)\n")
-	fmt.Printf("Enter choice (1-3): ")
+	fmt.Printf("  4. nkey - NKey seed file\n")
+	fmt.Printf("Enter choice (1-4): ")
 
 	reader := bufio.NewReader(os.Stdin)
 	choice, err := reader.ReadString('\n')
@@ -194,6 +328,8 @@ func promptForAuthMethod() (string, error) {
 		return config.NATSAuthToken, nil
 	case "3":
 		return config.NATSAuthCreds, nil
+	case "4":
+		return config.NATSAuthNKey, nil
 	default:
 		return "", fmt.Errorf("invalid choice: %s", choice)
 	}
@@ -202,7 +338,19 @@ func promptForAuthMethod() (string, error) {
 // configureUserPassAuth configures username/password authentication
 func configureUserPassAuth(ctx *config.Context) error {
 	username := natsUsername
-	password := natsPassword
+
+	passwordInput := utils.SecretInput{
+		Name:  "password",
+		Value: natsPassword,
+		Stdin: natsPasswordStdin,
+		File:  natsPasswordFile,
+	}
+	if err := passwordInput.Validate(); err != nil {
+		return err
+	}
+	if natsPasswordStdin && username == "" {
+		return fmt.Errorf("--password-stdin requires --username")
+	}
 
 	// Get username if not provided
 	if username == "" {
@@ -229,6 +377,22 @@ func configureUserPassAuth(ctx *config.Context) error {
 		}
 	}
 
+	password, err := passwordInput.Resolve()
+	if err != nil {
+		return err
+	}
+
+	// The password comes from the environment at connect time and is not stored
+	if password == "" && os.Getenv(natsClient.EnvPassword) != "" {
+		utils.PrintInfo(fmt.Sprintf("Password will be read from %s", natsClient.EnvPassword))
+		ctx.NATS.Username = username
+		ctx.NATS.Password = ""
+		ctx.NATS.Token = ""
+		ctx.NATS.CredsFile = ""
+		ctx.NATS.NKeyFile = ""
+		return nil
+	}
+
 	// Get password if not provided
 	if password == "" {
 		fmt.Print("Password: ")
@@ -250,13 +414,33 @@ func configureUserPassAuth(ctx *config.Context) error {
 	// Clear other auth fields
 	ctx.NATS.Token = ""
 	ctx.NATS.CredsFile = ""
+	ctx.NATS.NKeyFile = ""
 
 	return nil
 }
 
 // configureTokenAuth configures JWT token authentication
 func configureTokenAuth(ctx *config.Context) error {
-	token := natsToken
+	token, err := utils.SecretInput{
+		Name:  "token",
+		Value: natsToken,
+		Stdin: natsTokenStdin,
+		File:  natsTokenFile,
+	}.Resolve()
+	if err != nil {
+		return err
+	}
+
+	// The token comes from the environment at connect time and is not stored
+	if token == "" && os.Getenv(natsClient.EnvToken) != "" {
+		utils.PrintInfo(fmt.Sprintf("Token will be read from %s", natsClient.EnvToken))
+		ctx.NATS.Token = ""
+		ctx.NATS.Username = ""
+		ctx.NATS.Password = ""
+		ctx.NATS.CredsFile = ""
+		ctx.NATS.NKeyFile = ""
+		return nil
+	}
 
 	// Get token if not provided
 	if token == "" {
@@ -298,6 +482,7 @@ func configureTokenAuth(ctx *config.Context) error {
 	ctx.NATS.Username = ""
 	ctx.NATS.Password = ""
 	ctx.NATS.CredsFile = ""
+	ctx.NATS.NKeyFile = ""
 
 	return nil
 }
@@ -385,24 +570,271 @@ func configureCredsAuth(ctx *config.Context) error {
 	ctx.NATS.Username = ""
 	ctx.NATS.Password = ""
 	ctx.NATS.Token = ""
+	ctx.NATS.NKeyFile = ""
 
 	return nil
 }
 
+// configureNKeyAuth configures NKey seed file authentication
+func configureNKeyAuth(ctx *config.Context) error {
+	seedFile := natsNKeyFile
+
+	// Get seed file path if not provided
+	if seedFile == "" {
+		defaultPath := configManager.GetContextNKeyPath(ctx.Name)
+		if ctx.NATS.NKeyFile != "" {
+			fmt.Printf("Current NKey seed file: %s\n", configManager.ResolveContextPath(ctx.Name, ctx.NATS.NKeyFile))
+			fmt.Print("New NKey seed file path (press Enter to keep current): ")
+		} else {
+			fmt.Printf("NKey seed file path (press Enter for %s): ", defaultPath)
+		}
+
+		reader := bufio.NewReader(os.Stdin)
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read NKey seed file path: %w", err)
+		}
+
+		input = strings.TrimSpace(input)
+		switch {
+		case input != "":
+			seedFile = input
+		case ctx.NATS.NKeyFile != "":
+			seedFile = ctx.NATS.NKeyFile
+		default:
+			seedFile = defaultPath
+		}
+	}
+
+	// Store seeds inside the context directory as relative paths, like creds files
+	finalSeedFile := contextFilePath(ctx.Name, seedFile)
+
+	// The seed must exist and be a user seed; a typo here would only show up at connect time
+	seedPath := configManager.ResolveContextPath(ctx.Name, finalSeedFile)
+	if _, err := natsClient.NKeyPublicKey(seedPath); err != nil {
+		return err
+	}
+	if info, err := os.Stat(seedPath); err == nil && info.Mode().Perm()&0077 != 0 {
+		utils.PrintWarning(fmt.Sprintf("NKey seed file is accessible by other users (mode %s). Run 'chmod 600 %s'",
+			info.Mode().Perm(), seedPath))
+	}
+
+	// Update context
+	ctx.NATS.NKeyFile = finalSeedFile
+	// Clear other auth fields
+	ctx.NATS.Username = ""
+	ctx.NATS.Password = ""
+	ctx.NATS.Token = ""
+	ctx.NATS.CredsFile = ""
+
+	return nil
+}
+
+// configureNATSTLS applies the --tls-* flags to the context and checks that the files load
+func configureNATSTLS(cmd *cobra.Command, ctx *config.Context) error {
+	settings := []struct {
+		flag  string
+		value string
+		field *string
+		file  bool
+	}{
+		{"tls-ca", natsTLSCAFile, &ctx.NATS.TLSCAFile, true},
+		{"tls-cert", natsTLSCertFile, &ctx.NATS.TLSCertFile, true},
+		{"tls-key", natsTLSKeyFile, &ctx.NATS.TLSKeyFile, true},
+		{"tls-server-name", natsTLSServerName, &ctx.NATS.TLSServerName, false},
+		{"tls-min-version", natsTLSMinVersion, &ctx.NATS.TLSMinVersion, false},
+	}
+
+	for _, setting := range settings {
+		if !cmd.Flags().Changed(setting.flag) {
+			continue
+		}
+		value := strings.TrimSpace(setting.value)
+		if setting.file && value != "" {
+			value = contextFilePath(ctx.Name, value)
+		}
+		*setting.field = value
+		if value != "" {
+			ctx.NATS.TLSEnabled = true
+		}
+	}
+
+	resolved := configManager.ResolveNATSConfig(ctx)
+	if err := natsClient.CheckTLSFiles(&resolved); err != nil {
+		return err
+	}
+	if resolved.TLSKeyFile != "" {
+		if info, err := os.Stat(resolved.TLSKeyFile); err == nil && info.Mode().Perm()&0077 != 0 {
+			utils.PrintWarning(fmt.Sprintf("TLS client key is accessible by other users (mode %s). Run 'chmod 600 %s'",
+				info.Mode().Perm(), resolved.TLSKeyFile))
+		}
+	}
+
+	return nil
+}
+
+// printNATSTLSSettings prints the TLS files and overrides configured for a context
+func printNATSTLSSettings(ctx *config.Context) {
+	resolved := configManager.ResolveNATSConfig(ctx)
+
+	if ctx.NATS.TLSCAFile != "" {
+		fmt.Printf("  TLS CA Bundle: %s%s\n", ctx.NATS.TLSCAFile, certificateExpiryNote(resolved.TLSCAFile))
+	}
+	if ctx.NATS.TLSCertFile != "" {
+		fmt.Printf("  TLS Client Certificate: %s%s\n", ctx.NATS.TLSCertFile, certificateExpiryNote(resolved.TLSCertFile))
+		fmt.Printf("  TLS Client Key: %s\n", ctx.NATS.TLSKeyFile)
+	}
+	if ctx.NATS.TLSServerName != "" {
+		fmt.Printf("  TLS Server Name: %s\n", ctx.NATS.TLSServerName)
+	}
+	if ctx.NATS.TLSMinVersion != "" {
+		fmt.Printf("  TLS Min Version: %s\n", ctx.NATS.TLSMinVersion)
+	}
+}
+
+// certificateExpiryNote describes when the certificates in a file expire
+func certificateExpiryNote(path string) string {
+	expires, err := utils.CertificateExpiry(path)
+	if err != nil {
+		return ""
+	}
+	if time.Until(expires) <= 0 {
+		return fmt.Sprintf(" (expired %s)", expires.Format("2006-01-02"))
+	}
+	return fmt.Sprintf(" (expires %s)", expires.Format("2006-01-02"))
+}
+
+// contextFilePath returns how a file path is stored in a context: relative
+// ("./...") inside the context directory, absolute anywhere else
+func contextFilePath(contextName, path string) string {
+	if strings.HasPrefix(path, "./") {
+		return path
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+
+	relPath, err := filepath.Rel(configManager.GetContextDir(contextName), absPath)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return absPath
+	}
+
+	relative := "./" + relPath
+	utils.PrintInfo(fmt.Sprintf("Using relative path: %s", relative))
+	return relative
+}
+
+// anyFlagChanged reports whether any of the named flags was set on the command line
+func anyFlagChanged(cmd *cobra.Command, names []string) bool {
+	for _, name := range names {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// configureFromClientAuth configures NATS authentication from a PocketBase
+// client record and returns the auth method it requires
+func configureFromClientAuth(ctx *config.Context, ref string) (string, error) {
+	client, err := fetchNATSClientRecord(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	name := client.GetNATSUsername()
+	if name == "" {
+		name = client.GetID()
+	}
+	utils.PrintInfo(fmt.Sprintf("Using client %s (%s)", name, client.GetID()))
+	if !client.IsActive() {
+		utils.PrintWarning(fmt.Sprintf("Client %s is not active; the NATS server will likely reject it", name))
+	}
+
+	// Bearer-token clients present their JWT directly and have no seed to sign with
+	if client.IsBearerToken() && client.GetJWT() != "" {
+		ctx.NATS.Token = client.GetJWT()
+		ctx.NATS.Username = ""
+		ctx.NATS.Password = ""
+		ctx.NATS.CredsFile = ""
+		ctx.NATS.NKeyFile = ""
+		return config.NATSAuthToken, nil
+	}
+
+	creds := client.GetCredsFile()
+	if creds == "" {
+		return "", fmt.Errorf("client %s has no credentials file. It may not have been provisioned yet", name)
+	}
+	if _, err := nkeys.ParseDecoratedJWT([]byte(creds)); err != nil {
+		return "", fmt.Errorf("client %s has an invalid credentials file: %w", name, err)
+	}
+
+	credsPath := configManager.GetContextCredsPath(ctx.Name)
+	if err := os.WriteFile(credsPath, []byte(creds), 0600); err != nil {
+		return "", fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(credsPath, 0600); err != nil {
+		return "", fmt.Errorf("failed to secure credentials file: %w", err)
+	}
+	utils.PrintInfo(fmt.Sprintf("Wrote credentials to %s", credsPath))
+
+	ctx.NATS.CredsFile = "./" + filepath.Base(credsPath)
+	ctx.NATS.Username = ""
+	ctx.NATS.Password = ""
+	ctx.NATS.Token = ""
+	ctx.NATS.NKeyFile = ""
+	return config.NATSAuthCreds, nil
+}
+
+// fetchNATSClientRecord looks up a client record by ID, falling back to its NATS username
+func fetchNATSClientRecord(ctx *config.Context, ref string) (*pocketbase.ClientRecord, error) {
+	if !pocketbase.HasSession(ctx) {
+		return nil, fmt.Errorf("not authenticated with PocketBase. Run 'flint auth pb' first")
+	}
+
+	resolved := *ctx
+	resolved.PocketBase = configManager.ResolvePocketBaseConfig(ctx)
+	pb := pocketbase.NewClientFromContext(&resolved)
+	if err := pb.CredentialError(); err != nil {
+		return nil, err
+	}
+	pb.EnableAutoRefresh(configManager, ctx)
+
+	collection := config.GetStoneAgeCollections().Clients
+
+	record, err := pb.GetRecord(collection, ref, nil)
+	if err == nil {
+		return &pocketbase.ClientRecord{Record: record}, nil
+	}
+	var pbErr *pocketbase.PocketBaseError
+	if !errors.As(err, &pbErr) || !pbErr.IsNotFoundError() {
+		return nil, fmt.Errorf("failed to fetch client '%s': %w", ref, err)
+	}
+
+	filter := "nats_username = " + pocketbase.QuoteFilterString(ref)
+	list, err := pb.ListRecords(collection, &pocketbase.ListOptions{Filter: filter, PerPage: 2})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up client '%s': %w", ref, err)
+	}
+	switch len(list.Items) {
+	case 0:
+		return nil, fmt.Errorf("no client with ID or NATS username '%s' found in the %s collection", ref, collection)
+	case 1:
+		return &pocketbase.ClientRecord{Record: list.Items[0]}, nil
+	default:
+		return nil, fmt.Errorf("more than one client has NATS username '%s'; use the record ID", ref)
+	}
+}
+
 // testNATSConnection tests the NATS connection with the configured credentials
 func testNATSConnection(ctx *config.Context) error {
-	client := natsClient.NewClientFromContext(ctx)
-	
-	// Resolve credentials file path if relative
-	if ctx.NATS.CredsFile != "" && strings.HasPrefix(ctx.NATS.CredsFile, "./") {
-		contextDir := configManager.GetContextDir(ctx.Name)
-		actualCredsFile := filepath.Join(contextDir, ctx.NATS.CredsFile[2:])
-		
-		// Temporarily update the client's creds file for testing
-		tempConfig := ctx.NATS
-		tempConfig.CredsFile = actualCredsFile
-		client = natsClient.NewClient(&tempConfig)
-	}
+	// Resolve file paths relative to the context directory
+	testCtx := *ctx
+	testCtx.NATS = configManager.ResolveNATSConfig(ctx)
+	client := natsClient.NewClientFromContext(&testCtx)
 	
 	// Test connection
 	if err := client.Connect(); err != nil {
@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/fatih/color"
+	"flint-cli/internal/pocketbase"
+	"flint-cli/internal/utils"
+)
+
+// oauth2LoginTimeout is how long to wait for the browser to come back
+const oauth2LoginTimeout = 5 * time.Minute
+
+// oauth2Callback carries the result of the provider redirect
+type oauth2Callback struct {
+	code string
+	err  error
+}
+
+// authenticateWithOAuth2 runs the browser-based OAuth2 login against a PocketBase provider
+func authenticateWithOAuth2(client *pocketbase.Client, collection, providerName string) (*pocketbase.AuthResponse, error) {
+	methods, err := client.ListAuthMethods(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := methods.FindOAuth2Provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := provider.VerifyPKCE(); err != nil {
+		return nil, fmt.Errorf("refusing to start OAuth2 login: %w", err)
+	}
+
+	// Loopback callback server (RFC 8252)
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", pbOAuth2Port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start OAuth2 callback server: %w", err)
+	}
+	redirectURL := fmt.Sprintf("http://%s/callback", listener.Addr().String())
+
+	authURL, err := provider.AuthorizationURL(redirectURL)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	results := make(chan oauth2Callback, 1)
+	server := &http.Server{
+		Handler:           oauth2CallbackHandler(provider.State, results),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go server.Serve(listener)
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	displayName := provider.DisplayName
+	if displayName == "" {
+		displayName = provider.Name
+	}
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	if pbNoBrowser {
+		fmt.Printf("Open the following URL in your browser to sign in with %s:\n\n  %s\n\n", displayName, cyan(authURL))
+	} else {
+		utils.PrintInfo(fmt.Sprintf("Opening your browser to sign in with %s...", displayName))
+		if err := openBrowser(authURL); err != nil {
+			utils.PrintWarning(fmt.Sprintf("Could not open a browser: %v", err))
+		}
+		fmt.Printf("If the browser does not open, visit:\n\n  %s\n\n", cyan(authURL))
+	}
+	fmt.Printf("Waiting for the sign-in to complete (press Ctrl+C to cancel)...\n")
+
+	var result oauth2Callback
+	select {
+	case result = <-results:
+	case <-time.After(oauth2LoginTimeout):
+		return nil, fmt.Errorf("timed out after %s waiting for the OAuth2 sign-in", oauth2LoginTimeout)
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	utils.PrintInfo(fmt.Sprintf("Authenticating with collection '%s' via %s...", collection, displayName))
+	return client.AuthenticateWithOAuth2(collection, provider, result.code, redirectURL)
+}
+
+// oauth2CallbackHandler handles the provider redirect, checking the state before accepting the code
+func oauth2CallbackHandler(expectedState string, results chan<- oauth2Callback) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		var result oauth2Callback
+		switch {
+		case query.Get("error") != "":
+			result.err = fmt.Errorf("OAuth2 provider returned an error: %s %s",
+				query.Get("error"), query.Get("error_description"))
+		case subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(expectedState)) != 1:
+			// Possibly a forged or stale redirect; keep waiting for the real one
+			writeOAuth2Page(w, http.StatusBadRequest, "Sign-in failed", "The state parameter did not match. Please try again.")
+			return
+		case query.Get("code") == "":
+			result.err = fmt.Errorf("OAuth2 callback did not include an authorization code")
+		default:
+			result.code = query.Get("code")
+		}
+
+		if result.err != nil {
+			writeOAuth2Page(w, http.StatusBadRequest, "Sign-in failed", result.err.Error())
+		} else {
+			writeOAuth2Page(w, http.StatusOK, "Sign-in complete", "You can close this window and return to the terminal.")
+		}
+
+		// Only the first valid callback counts
+		select {
+		case results <- result:
+		default:
+		}
+	})
+	return mux
+}
+
+// writeOAuth2Page writes a minimal HTML page to the browser
+func writeOAuth2Page(w http.ResponseWriter, status int, title, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<!DOCTYPE html><html><head><title>flint: %s</title></head>"+
+		"<body style=\"font-family:sans-serif;margin:3em\"><h2>%s</h2><p>%s</p></body></html>",
+		html.EscapeString(title), html.EscapeString(title), html.EscapeString(message))
+}
+
+// openBrowser opens url in the user's default browser
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// oauth2Identity returns a display identity for an OAuth2 login
+func oauth2Identity(authResp *pocketbase.AuthResponse, provider string) string {
+	if identity := recordIdentity(authResp.Record); identity != "" {
+		return fmt.Sprintf("%s (via %s)", identity, provider)
+	}
+	return provider
+}
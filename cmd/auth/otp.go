@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
+	"flint-cli/internal/pocketbase"
+	"flint-cli/internal/utils"
+)
+
+// loginWithOTP requests a one-time password for email and completes the login
+// with it. code is the --otp value; when empty the code is prompted for.
+func loginWithOTP(client *pocketbase.Client, collection, email, mfaID, code string) (*pocketbase.AuthResponse, error) {
+	utils.PrintInfo(fmt.Sprintf("Requesting a one-time password for %s...", email))
+	otpID, err := client.RequestOTP(collection, email)
+	if err != nil {
+		return nil, err
+	}
+	utils.PrintInfo("Check your email for the one-time password")
+
+	return completeOTPLogin(client, collection, otpID, mfaID, code)
+}
+
+// completeMFA finishes a multi-factor login with the factor the first login did
+// not use: an emailed OTP after a password, or the password after an OTP or
+// OAuth2 login
+func completeMFA(client *pocketbase.Client, collection, mfaID string, afterPassword bool, passwordInput utils.SecretInput) (*pocketbase.AuthResponse, error) {
+	utils.PrintInfo("Multi-factor authentication is required")
+
+	email := pbEmail
+	if email == "" {
+		var err error
+		if email, err = promptForEmail(); err != nil {
+			return nil, fmt.Errorf("failed to get email: %w", err)
+		}
+	}
+
+	if afterPassword {
+		// The --otp value belonged to the first step, so the code is always prompted for
+		return loginWithOTP(client, collection, email, mfaID, "")
+	}
+
+	password, err := passwordInput.Resolve()
+	if err != nil {
+		return nil, err
+	}
+	if password == "" {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			resume := fmt.Sprintf("flint auth pb --collection %s --email %s --mfa-id %s --password-stdin", collection, email, mfaID)
+			fmt.Printf("MFA ID: %s\n", mfaID)
+			fmt.Printf("\nComplete the login with:\n  %s\n", color.New(color.FgCyan).Sprint(resume))
+			return nil, fmt.Errorf("password required")
+		}
+		if password, err = promptForPassword(); err != nil {
+			return nil, fmt.Errorf("failed to get password: %w", err)
+		}
+	}
+
+	utils.PrintInfo(fmt.Sprintf("Authenticating with collection '%s'...", collection))
+	return client.AuthenticateWithPassword(collection, email, password, mfaID)
+}
+
+// completeOTPLogin authenticates with an OTP code, prompting for it when code is empty
+func completeOTPLogin(client *pocketbase.Client, collection, otpID, mfaID, code string) (*pocketbase.AuthResponse, error) {
+	if code == "" {
+		var err error
+		if code, err = readOTPCode(collection, otpID, mfaID); err != nil {
+			return nil, err
+		}
+	}
+
+	utils.PrintInfo(fmt.Sprintf("Authenticating with collection '%s'...", collection))
+	return client.AuthenticateWithOTP(collection, otpID, code, mfaID)
+}
+
+// readOTPCode prompts for the OTP code. Without a terminal it prints the IDs
+// needed to finish the login with a second command.
+func readOTPCode(collection, otpID, mfaID string) (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		resume := fmt.Sprintf("flint auth pb --collection %s --otp-id %s", collection, otpID)
+		if mfaID != "" {
+			resume += " --mfa-id " + mfaID
+		}
+		fmt.Printf("OTP ID: %s\n", otpID)
+		if mfaID != "" {
+			fmt.Printf("MFA ID: %s\n", mfaID)
+		}
+		fmt.Printf("\nComplete the login with:\n  %s\n", color.New(color.FgCyan).Sprint(resume+" --otp <code>"))
+		return "", fmt.Errorf("one-time password required")
+	}
+
+	fmt.Print("One-time password: ")
+	reader := bufio.NewReader(os.Stdin)
+	code, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("failed to read one-time password: %w", err)
+	}
+
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return "", fmt.Errorf("one-time password is required")
+	}
+	return code, nil
+}
+
+// recordIdentity returns the email or ID of an auth record
+func recordIdentity(record map[string]interface{}) string {
+	if email, ok := record["email"].(string); ok && email != "" {
+		return email
+	}
+	if id, ok := record["id"].(string); ok {
+		return id
+	}
+	return ""
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -20,6 +21,19 @@ var (
 	pbPassword   string
 	pbCollection string
 	pbOrgID      string
+	pbSuperuser  bool
+
+	pbPasswordStdin bool
+	pbPasswordFile  string
+
+	pbOAuth2Provider string
+	pbOAuth2Port     int
+	pbNoBrowser      bool
+
+	pbWithOTP bool
+	pbOTP     string
+	pbOTPID   string
+	pbMFAID   string
 )
 
 var pbCmd = &cobra.Command{
@@ -36,6 +50,28 @@ Collections:
   edges        Edge device authentication  
   things       Individual IoT device authentication
   service_users System service accounts
+  _superusers  PocketBase superusers / platform operators (or use --superuser)
+
+Instead of a password you can sign in through an OAuth2 (SSO) provider
+configured for the collection in PocketBase. flint starts a temporary callback
+server on 127.0.0.1, opens the provider's sign-in page in your browser, and
+completes the login when the provider redirects back. The provider must allow
+http://127.0.0.1:<port>/callback as a redirect URL; use --oauth2-port to pin
+the port.
+
+Collections with OTP enabled accept a one-time password sent by email
+(--with-otp). When multi-factor authentication is enabled, a password login
+is followed by an emailed OTP, and an OTP or OAuth2 login is followed by the
+password (taken from --password, --password-stdin, --password-file or
+FLINT_PB_PASSWORD, or prompted for). Without a terminal, the OTP and MFA IDs
+are printed so the login can be completed with a second call using --otp-id,
+--mfa-id and --otp, or --mfa-id and a password.
+
+For CI pipelines, --password-stdin and --password-file keep the password out
+of the command line, and FLINT_PB_PASSWORD is used when no password flag is
+given. Alternatively set FLINT_PB_TOKEN to a pre-issued session token: every
+command then uses it directly instead of the context's stored session, and it
+is never written to disk (no 'flint auth pb' needed).
 
 The authentication will:
 1. Validate your credentials with PocketBase
@@ -54,13 +90,37 @@ Examples:
   flint auth pb --collection edges --email edge001@company.com
 
   # Authenticate and set organization
-  flint auth pb --email admin@company.com --organization org_abc123def456`,
+  flint auth pb --email admin@company.com --organization org_abc123def456
+
+  # Authenticate as a platform operator (PocketBase superuser)
+  flint auth pb --superuser --email ops@stone-age.io
+
+  # Sign in with a one-time password sent by email
+  flint auth pb --with-otp --email admin@company.com
+
+  # Complete a login in scripts (IDs printed by the first call)
+  flint auth pb --otp-id <otp_id> --mfa-id <mfa_id> --otp 123456
+
+  # Non-interactive login for CI
+  echo "$PB_PASSWORD" | flint auth pb --email ci@company.com --password-stdin
+  FLINT_PB_PASSWORD=secret flint auth pb --email ci@company.com
+
+  # Sign in through an SSO provider
+  flint auth pb --oauth2 google
+  flint auth pb --oauth2 microsoft --oauth2-port 8765 --no-browser`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, err := validateActiveContext()
 		if err != nil {
 			return err
 		}
 
+		if pbSuperuser {
+			if pbCollection != "" && pbCollection != config.AuthCollectionSuperusers {
+				return fmt.Errorf("--superuser cannot be combined with --collection %s", pbCollection)
+			}
+			pbCollection = config.AuthCollectionSuperusers
+		}
+
 		// Use collection from context if not specified
 		if pbCollection == "" {
 			pbCollection = ctx.PocketBase.AuthCollection
@@ -71,28 +131,63 @@ Examples:
 			return err
 		}
 
+		passwordInput := utils.SecretInput{
+			Name:   "password",
+			Value:  pbPassword,
+			Stdin:  pbPasswordStdin,
+			File:   pbPasswordFile,
+			EnvVar: pocketbase.EnvPassword,
+		}
+		if err := passwordInput.Validate(); err != nil {
+			return err
+		}
+
+		if (pbWithOTP || pbOTPID != "") && pbOAuth2Provider != "" {
+			return fmt.Errorf("--with-otp and --otp-id cannot be combined with --oauth2")
+		}
+		if pbOTP != "" && !pbWithOTP && pbOTPID == "" && pbMFAID == "" {
+			return fmt.Errorf("--otp requires --with-otp, --otp-id or --mfa-id")
+		}
+
 		// Get credentials if not provided
-		if pbEmail == "" {
-			pbEmail, err = promptForEmail()
-			if err != nil {
-				return fmt.Errorf("failed to get email: %w", err)
-			}
+		usePassword := pbOAuth2Provider == "" && !pbWithOTP && pbOTPID == ""
+		if pbPasswordStdin && pbEmail == "" {
+			return fmt.Errorf("--password-stdin requires --email")
 		}
+		if usePassword || (pbWithOTP && pbOTPID == "") {
+			if pbEmail == "" {
+				pbEmail, err = promptForEmail()
+				if err != nil {
+					return fmt.Errorf("failed to get email: %w", err)
+				}
+			}
 
-		if pbPassword == "" {
+			// Basic email validation
+			if pbEmail == "" || !strings.Contains(pbEmail, "@") {
+				return fmt.Errorf("invalid email format")
+			}
+		}
+		if usePassword {
+			if pbPassword, err = passwordInput.Resolve(); err != nil {
+				return err
+			}
+		}
+		if usePassword && pbPassword == "" {
 			pbPassword, err = promptForPassword()
 			if err != nil {
 				return fmt.Errorf("failed to get password: %w", err)
 			}
 		}
 
-		// Basic email validation
-		if pbEmail == "" || !strings.Contains(pbEmail, "@") {
-			return fmt.Errorf("invalid email format")
-		}
+		// Arguments are valid; from here on usage help would only hide server errors
+		cmd.SilenceUsage = true
 
 		// Create PocketBase client
-		client := pocketbase.NewClient(ctx.PocketBase.URL)
+		pbConfig := configManager.ResolvePocketBaseConfig(ctx)
+		client, err := pocketbase.NewClientWithConfig(&pbConfig)
+		if err != nil {
+			return err
+		}
 
 		// Test connection first
 		utils.PrintInfo("Testing connection to PocketBase...")
@@ -101,98 +196,150 @@ Examples:
 		}
 
 		// Perform authentication
-		utils.PrintInfo(fmt.Sprintf("Authenticating with collection '%s'...", pbCollection))
-		
-		authResp, err := client.Authenticate(pbCollection, pbEmail, pbPassword)
-		if err != nil {
-			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
-				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
-				if suggestion := pbErr.GetSuggestion(); suggestion != "" {
-					fmt.Printf("\nSuggestion: %s\n", suggestion)
-				}
-				return fmt.Errorf("authentication failed")
+		var authResp *pocketbase.AuthResponse
+		identity := pbEmail
+		switch {
+		case pbOTPID != "":
+			// Second half of a scripted OTP or MFA login
+			authResp, err = completeOTPLogin(client, pbCollection, pbOTPID, pbMFAID, pbOTP)
+		case pbWithOTP:
+			authResp, err = loginWithOTP(client, pbCollection, pbEmail, pbMFAID, pbOTP)
+		case pbOAuth2Provider != "":
+			authResp, err = authenticateWithOAuth2(client, pbCollection, pbOAuth2Provider)
+			if err == nil {
+				identity = oauth2Identity(authResp, pbOAuth2Provider)
 			}
-			return fmt.Errorf("authentication failed: %w", err)
+		default:
+			utils.PrintInfo(fmt.Sprintf("Authenticating with collection '%s'...", pbCollection))
+			authResp, err = client.AuthenticateWithPassword(pbCollection, pbEmail, pbPassword, pbMFAID)
 		}
 
-		// Handle organization validation for user accounts
-		if pbCollection == config.AuthCollectionUsers {
-			orgID, err := handleUserOrganization(client, authResp)
-			if err != nil {
-				return err
-			}
-			if orgID != "" {
-				pbOrgID = orgID
-			}
+		// The first factor succeeded; finish with the other one
+		var mfaErr *pocketbase.MFARequiredError
+		if errors.As(err, &mfaErr) {
+			authResp, err = completeMFA(client, pbCollection, mfaErr.MFAID, usePassword, passwordInput)
 		}
-
-		// Update context with authentication data
-		if err := pocketbase.UpdateAuthContextFromResponse(ctx, authResp, pbOrgID); err != nil {
-			return fmt.Errorf("failed to update context: %w", err)
+		if err != nil {
+			return authFailure(err)
 		}
-
-		// Save updated context
-		if err := configManager.SaveContext(ctx); err != nil {
-			return fmt.Errorf("failed to save authentication: %w", err)
+		if identity == "" {
+			identity = recordIdentity(authResp.Record)
 		}
 
-		// Update PocketBase current_organization_id if we're a user and have an org
-		if pbCollection == config.AuthCollectionUsers && pbOrgID != "" {
-			utils.PrintInfo("Updating current organization in PocketBase...")
-			if err := client.UpdateCurrentOrganization(pbOrgID); err != nil {
-				utils.PrintWarning(fmt.Sprintf("Failed to update current organization in PocketBase: %v", err))
-				// Don't fail the authentication for this
-			}
-		}
+		return completePBLogin(ctx, client, authResp, identity)
+	},
+}
 
-		// Display success message
-		green := color.New(color.FgGreen).SprintFunc()
-		cyan := color.New(color.FgCyan).SprintFunc()
-		
-		fmt.Printf("\n%s Authentication successful!\n", green("✓"))
-		
-		// Show authentication details
-		fmt.Printf("\nAuthentication Details:\n")
-		fmt.Printf("  Collection: %s\n", pocketbase.GetCollectionDisplayName(pbCollection))
-		fmt.Printf("  Identity: %s\n", pbEmail)
-		fmt.Printf("  Context: %s\n", cyan(ctx.Name))
-		
-		if authResp.Record != nil {
-			if name := getRecordDisplayName(authResp.Record, pbCollection); name != "" {
-				fmt.Printf("  Name: %s\n", name)
-			}
+// authFailure prints a friendly message for PocketBase errors and returns the authentication error
+func authFailure(err error) error {
+	var pbErr *pocketbase.PocketBaseError
+	if errors.As(err, &pbErr) {
+		utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+		if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+			fmt.Printf("\nSuggestion: %s\n", suggestion)
 		}
+		return fmt.Errorf("authentication failed")
+	}
+	return fmt.Errorf("authentication failed: %w", err)
+}
 
-		if pbOrgID != "" {
-			fmt.Printf("  Organization: %s\n", cyan(pbOrgID))
+// completePBLogin stores a successful authentication in the context, whatever method produced it
+func completePBLogin(ctx *config.Context, client *pocketbase.Client, authResp *pocketbase.AuthResponse, identity string) error {
+	// Handle organization validation for user accounts
+	if pbCollection == config.AuthCollectionUsers {
+		orgID, err := handleUserOrganization(client, authResp)
+		if err != nil {
+			return err
+		}
+		if orgID != "" {
+			pbOrgID = orgID
 		}
+	}
 
-		// Show available next steps
-		fmt.Printf("\nNext steps:\n")
-		fmt.Printf("  View your profile: %s\n", 
-			color.New(color.FgCyan).Sprint("flint collections users get $(flint context show --output json | jq -r '.pocketbase.auth_record.id')"))
-		
-		if pbOrgID != "" {
-			fmt.Printf("  List organization resources: %s\n", 
-				color.New(color.FgCyan).Sprint("flint collections edges list"))
-			fmt.Printf("  View organization details: %s\n", 
-				color.New(color.FgCyan).Sprintf("flint collections organizations get %s", pbOrgID))
+	// Update context with authentication data; the collection is recorded so
+	// token refreshes go to the collection that issued the token
+	if err := pocketbase.UpdateAuthContextFromResponse(ctx, authResp, pbOrgID); err != nil {
+		return fmt.Errorf("failed to update context: %w", err)
+	}
+	ctx.PocketBase.AuthCollection = pbCollection
+	ctx.PocketBase.ImpersonatedBy = ""
+
+	// Save updated context
+	if err := configManager.SaveContext(ctx); err != nil {
+		return fmt.Errorf("failed to save authentication: %w", err)
+	}
+
+	// What the previous session could read says nothing about the new one
+	if err := pocketbase.InvalidateSchemaCache(ctx.Name); err != nil {
+		utils.PrintWarning(err.Error())
+	}
+
+	// Update PocketBase current_organization_id if we're a user and have an org
+	if pbCollection == config.AuthCollectionUsers && pbOrgID != "" {
+		utils.PrintInfo("Updating current organization in PocketBase...")
+		if err := client.UpdateCurrentOrganization(pbOrgID); err != nil {
+			utils.PrintWarning(fmt.Sprintf("Failed to update current organization in PocketBase: %v", err))
+			// Don't fail the authentication for this
 		}
+	}
 
-		if pbCollection == config.AuthCollectionUsers && pbOrgID == "" {
-			fmt.Printf("  Set organization: %s\n", 
-				color.New(color.FgCyan).Sprint("flint context organization <org_id>"))
+	// Display success message
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	
+	fmt.Printf("\n%s Authentication successful!\n", green("✓"))
+	
+	// Show authentication details
+	fmt.Printf("\nAuthentication Details:\n")
+	fmt.Printf("  Collection: %s\n", pocketbase.GetCollectionDisplayName(pbCollection))
+	fmt.Printf("  Identity: %s\n", identity)
+	fmt.Printf("  Context: %s\n", cyan(ctx.Name))
+	
+	if authResp.Record != nil {
+		if name := getRecordDisplayName(authResp.Record, pbCollection); name != "" {
+			fmt.Printf("  Name: %s\n", name)
 		}
+	}
 
-		return nil
-	},
+	if pbOrgID != "" {
+		fmt.Printf("  Organization: %s\n", cyan(pbOrgID))
+	}
+
+	// Show available next steps
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  View your profile: %s\n", 
+		color.New(color.FgCyan).Sprint("flint collections users get $(flint context show --output json | jq -r '.pocketbase.auth_record.id')"))
+	
+	if pbOrgID != "" {
+		fmt.Printf("  List organization resources: %s\n", 
+			color.New(color.FgCyan).Sprint("flint collections edges list"))
+		fmt.Printf("  View organization details: %s\n", 
+			color.New(color.FgCyan).Sprintf("flint collections organizations get %s", pbOrgID))
+	}
+
+	if pbCollection == config.AuthCollectionUsers && pbOrgID == "" {
+		fmt.Printf("  Set organization: %s\n", 
+			color.New(color.FgCyan).Sprint("flint context organization <org_id>"))
+	}
+
+	return nil
 }
 
 func init() {
 	pbCmd.Flags().StringVarP(&pbEmail, "email", "e", "", "Email address for authentication")
 	pbCmd.Flags().StringVarP(&pbPassword, "password", "p", "", "Password for authentication (will prompt if not provided)")
-	pbCmd.Flags().StringVarP(&pbCollection, "collection", "c", "", "Authentication collection (users|clients|edges|things|service_users)")
+	pbCmd.Flags().BoolVar(&pbPasswordStdin, "password-stdin", false, "Read the password from standard input")
+	pbCmd.Flags().StringVar(&pbPasswordFile, "password-file", "", "Read the password from a file")
+	pbCmd.Flags().StringVarP(&pbCollection, "collection", "c", "", "Authentication collection (users|clients|edges|things|service_users|_superusers)")
 	pbCmd.Flags().StringVarP(&pbOrgID, "organization", "o", "", "Organization ID to set after authentication")
+	pbCmd.Flags().BoolVar(&pbSuperuser, "superuser", false, "Authenticate as a PocketBase superuser (_superusers collection)")
+	pbCmd.Flags().StringVar(&pbOAuth2Provider, "oauth2", "", "Sign in through an OAuth2 provider configured in PocketBase (e.g. google, microsoft)")
+	pbCmd.Flags().IntVar(&pbOAuth2Port, "oauth2-port", 0, "Local port for the OAuth2 callback (default: random free port)")
+	pbCmd.Flags().BoolVar(&pbNoBrowser, "no-browser", false, "Print the OAuth2 authorization URL instead of opening a browser")
+	pbCmd.Flags().BoolVar(&pbWithOTP, "with-otp", false, "Sign in with a one-time password sent by email")
+	pbCmd.Flags().StringVar(&pbOTP, "otp", "", "One-time password code (will prompt if not provided)")
+	pbCmd.Flags().StringVar(&pbOTPID, "otp-id", "", "OTP ID from a previous request, to complete a non-interactive login")
+	pbCmd.Flags().StringVar(&pbMFAID, "mfa-id", "", "MFA ID from a previous first-factor login")
 }
 
 // promptForEmail prompts the user for their email address
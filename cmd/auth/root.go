@@ -24,6 +24,7 @@ Stone-Age.io supports multiple authentication collections in PocketBase:
 - edges: Edge device authentication
 - things: Individual IoT device authentication
 - service_users: System service accounts
+- _superusers: PocketBase superusers (platform operators, see --superuser)
 
 NATS Authentication:
 NATS supports multiple authentication methods:
@@ -41,6 +42,19 @@ Examples:
   # Authenticate with PocketBase as an edge device
   flint auth pb --collection edges --email edge001@company.com --password secret
   
+  # Act as a specific user (requires a superuser session)
+  flint auth impersonate users u_abc123def456789 --duration 30m
+
+  # Show stored sessions and who you are
+  flint auth status --all-contexts
+  flint auth whoami
+
+  # Remove the stored session
+  flint auth logout
+
+  # Get credentials from an external command instead of storing them
+  flint auth helper pocketbase -- vault-flint token
+
   # Configure NATS username/password authentication
   flint auth nats --method user_pass --username client001 --password secret
   
@@ -61,6 +75,11 @@ func init() {
 	// Add subcommands
 	AuthCmd.AddCommand(pbCmd)
 	AuthCmd.AddCommand(natsCmd)
+	AuthCmd.AddCommand(impersonateCmd)
+	AuthCmd.AddCommand(statusCmd)
+	AuthCmd.AddCommand(whoamiCmd)
+	AuthCmd.AddCommand(logoutCmd)
+	AuthCmd.AddCommand(helperCmd)
 }
 
 // SetConfigManager sets the configuration manager for the auth commands
@@ -84,6 +103,9 @@ func validateActiveContext() (*config.Context, error) {
 
 	ctx, err := configManager.GetActiveContext()
 	if err != nil {
+		if config.IsContextNotFound(err) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("no active context set. Use 'flint context select <name>' to set one")
 	}
 
@@ -0,0 +1,360 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"flint-cli/internal/config"
+	natsClient "flint-cli/internal/nats"
+	"flint-cli/internal/pocketbase"
+	"flint-cli/internal/utils"
+)
+
+var (
+	statusOutputFormat string
+	statusAllContexts  bool
+)
+
+// AuthStatus describes the stored credentials of one context
+type AuthStatus struct {
+	Context    string           `json:"context" yaml:"context"`
+	Active     bool             `json:"active" yaml:"active"`
+	Error      string           `json:"error,omitempty" yaml:"error,omitempty"`
+	PocketBase PocketBaseStatus `json:"pocketbase" yaml:"pocketbase"`
+	NATS       NATSStatus       `json:"nats" yaml:"nats"`
+}
+
+// PocketBaseStatus describes a context's PocketBase session
+type PocketBaseStatus struct {
+	URL              string     `json:"url" yaml:"url"`
+	Authenticated    bool       `json:"authenticated" yaml:"authenticated"`
+	Identity         string     `json:"identity,omitempty" yaml:"identity,omitempty"`
+	Collection       string     `json:"collection" yaml:"collection"`
+	Organization     string     `json:"organization,omitempty" yaml:"organization,omitempty"`
+	ImpersonatedBy   string     `json:"impersonated_by,omitempty" yaml:"impersonated_by,omitempty"`
+	TokenSource      string     `json:"token_source,omitempty" yaml:"token_source,omitempty"`
+	CredentialHelper string     `json:"credential_helper,omitempty" yaml:"credential_helper,omitempty"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+	ExpiresIn        string     `json:"expires_in,omitempty" yaml:"expires_in,omitempty"`
+	Expired          bool       `json:"expired" yaml:"expired"`
+}
+
+// NATSStatus describes a context's NATS credentials
+type NATSStatus struct {
+	AuthMethod       string     `json:"auth_method" yaml:"auth_method"`
+	Configured       bool       `json:"configured" yaml:"configured"`
+	Username         string     `json:"username,omitempty" yaml:"username,omitempty"`
+	SecretSource     string     `json:"secret_source,omitempty" yaml:"secret_source,omitempty"`
+	CredentialHelper string     `json:"credential_helper,omitempty" yaml:"credential_helper,omitempty"`
+	CredsFile        string     `json:"creds_file,omitempty" yaml:"creds_file,omitempty"`
+	NKeyFile         string     `json:"nkey_file,omitempty" yaml:"nkey_file,omitempty"`
+	PublicKey        string     `json:"public_key,omitempty" yaml:"public_key,omitempty"`
+	CredsExpiresAt   *time.Time `json:"creds_expires_at,omitempty" yaml:"creds_expires_at,omitempty"`
+	CredsExpiresIn   string     `json:"creds_expires_in,omitempty" yaml:"creds_expires_in,omitempty"`
+	CredsExpired     bool       `json:"creds_expired" yaml:"creds_expired"`
+	CredsError       string     `json:"creds_error,omitempty" yaml:"creds_error,omitempty"`
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status [name]",
+	Short: "Show authentication status",
+	Long: `Show the stored PocketBase session and NATS credentials of a context.
+
+For PocketBase this shows the authenticated identity, auth collection,
+organization and how long the session token remains valid. For NATS it shows
+the auth method and, for creds authentication, when the user JWT in the
+credentials file expires.
+
+No network calls are made; use 'flint auth whoami' to check the session
+against the server, or 'flint context doctor' for a full connectivity check.
+
+Examples:
+  flint auth status
+  flint auth status production
+  flint auth status --all-contexts
+  flint auth status --all-contexts -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateConfigManager(); err != nil {
+			return err
+		}
+
+		format, err := authOutputFormat(statusOutputFormat)
+		if err != nil {
+			return err
+		}
+		if statusAllContexts && len(args) > 0 {
+			return fmt.Errorf("--all-contexts cannot be combined with a context name")
+		}
+
+		activeName, _, _ := configManager.GetActiveContextName()
+
+		var names []string
+		switch {
+		case statusAllContexts:
+			names, err = configManager.ListContexts()
+			if err != nil {
+				return err
+			}
+			if len(names) == 0 {
+				return fmt.Errorf("no contexts found. Use 'flint context create <name>' to create one")
+			}
+		case len(args) == 1:
+			if !configManager.ContextExists(args[0]) {
+				return fmt.Errorf("context '%s' not found", args[0])
+			}
+			names = []string{args[0]}
+		default:
+			if activeName == "" {
+				return fmt.Errorf("no active context set. Use 'flint context select <name>' to set one")
+			}
+			names = []string{activeName}
+		}
+
+		var statuses []AuthStatus
+		for _, name := range names {
+			statuses = append(statuses, collectAuthStatus(name, name == activeName))
+		}
+
+		if format != config.OutputFormatTable {
+			if statusAllContexts {
+				return utils.OutputData(statuses, format)
+			}
+			return utils.OutputData(statuses[0], format)
+		}
+
+		for i, status := range statuses {
+			if i > 0 {
+				fmt.Println()
+			}
+			printAuthStatus(status)
+		}
+
+		return nil
+	},
+}
+
+// collectAuthStatus builds the status of a context without contacting any server
+func collectAuthStatus(name string, active bool) AuthStatus {
+	status := AuthStatus{Context: name, Active: active}
+
+	var ctx *config.Context
+	var err error
+	if active {
+		// Includes .flint.yaml overrides such as the organization
+		ctx, err = configManager.GetActiveContext()
+	} else {
+		ctx, err = configManager.LoadContext(name)
+	}
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	pb := ctx.PocketBase
+	status.PocketBase = PocketBaseStatus{
+		URL:            pb.URL,
+		Authenticated:  pb.AuthToken != "",
+		Collection:     pb.AuthCollection,
+		Organization:   pb.OrganizationID,
+		ImpersonatedBy: pb.ImpersonatedBy,
+		ExpiresAt:      pb.AuthExpires,
+	}
+	if pb.AuthToken != "" {
+		status.PocketBase.Identity = recordIdentity(pb.AuthRecord)
+		status.PocketBase.TokenSource = "context"
+	}
+	// FLINT_PB_TOKEN or a credential helper replaces the stored session
+	if helper := pb.CredentialHelper; helper != nil {
+		status.PocketBase.Authenticated = true
+		status.PocketBase.Identity = ""
+		status.PocketBase.TokenSource = pocketbase.TokenSourceHelper
+		status.PocketBase.CredentialHelper = helper.Command
+		status.PocketBase.ExpiresAt = nil
+	}
+	if token := pocketbase.EnvAuthToken(); token != "" {
+		claims, _ := utils.JWTClaims(token)
+		identity, _ := claims["id"].(string)
+		status.PocketBase.Authenticated = true
+		status.PocketBase.Identity = identity
+		status.PocketBase.ImpersonatedBy = ""
+		status.PocketBase.TokenSource = pocketbase.EnvToken
+		status.PocketBase.ExpiresAt = pocketbase.TokenExpiry(token)
+	}
+	if status.PocketBase.Authenticated {
+		if expires := status.PocketBase.ExpiresAt; expires != nil {
+			left := time.Until(*expires)
+			status.PocketBase.Expired = left <= 0
+			if left > 0 {
+				status.PocketBase.ExpiresIn = utils.FormatDuration(int64(left.Seconds()))
+			}
+		}
+	}
+
+	nats := ctx.NATS
+	status.NATS = NATSStatus{AuthMethod: nats.AuthMethod}
+	if helper := nats.CredentialHelper; helper != nil &&
+		os.Getenv(natsClient.EnvPassword) == "" && os.Getenv(natsClient.EnvToken) == "" {
+		// The helper decides the method when flint connects
+		status.NATS.AuthMethod = ""
+		status.NATS.Configured = true
+		status.NATS.SecretSource = pocketbase.TokenSourceHelper
+		status.NATS.CredentialHelper = helper.Command
+		return status
+	}
+	switch nats.AuthMethod {
+	case config.NATSAuthUserPass:
+		status.NATS.Configured = nats.Username != "" && natsClient.Password(&nats) != ""
+		status.NATS.Username = nats.Username
+		if os.Getenv(natsClient.EnvPassword) != "" {
+			status.NATS.SecretSource = natsClient.EnvPassword
+		}
+	case config.NATSAuthToken:
+		status.NATS.Configured = natsClient.Token(&nats) != ""
+		if os.Getenv(natsClient.EnvToken) != "" {
+			status.NATS.SecretSource = natsClient.EnvToken
+		}
+	case config.NATSAuthCreds:
+		status.NATS.Configured = nats.CredsFile != ""
+		status.NATS.CredsFile = nats.CredsFile
+		if nats.CredsFile != "" {
+			expires, err := natsClient.CredsExpiry(configManager.ResolveContextPath(name, nats.CredsFile))
+			switch {
+			case err != nil:
+				status.NATS.CredsError = err.Error()
+			case expires != nil:
+				status.NATS.CredsExpiresAt = expires
+				left := time.Until(*expires)
+				status.NATS.CredsExpired = left <= 0
+				if left > 0 {
+					status.NATS.CredsExpiresIn = utils.FormatDuration(int64(left.Seconds()))
+				}
+			}
+		}
+	case config.NATSAuthNKey:
+		status.NATS.Configured = nats.NKeyFile != ""
+		status.NATS.NKeyFile = nats.NKeyFile
+		if nats.NKeyFile != "" {
+			publicKey, err := natsClient.NKeyPublicKey(configManager.ResolveContextPath(name, nats.NKeyFile))
+			if err != nil {
+				status.NATS.CredsError = err.Error()
+			}
+			status.NATS.PublicKey = publicKey
+		}
+	}
+
+	return status
+}
+
+// printAuthStatus prints the status of one context
+func printAuthStatus(status AuthStatus) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+
+	fmt.Printf("%s Auth Status: %s", bold("Stone-Age.io"), cyan(status.Context))
+	if status.Active {
+		fmt.Printf(" %s", green("(ACTIVE)"))
+	}
+	fmt.Println()
+	fmt.Println(strings.Repeat("=", 50))
+
+	if status.Error != "" {
+		fmt.Printf("  %s %s\n", red("Error:"), status.Error)
+		return
+	}
+
+	pb := status.PocketBase
+	fmt.Printf("%s\n", bold("PocketBase:"))
+	fmt.Printf("  URL:            %s\n", pb.URL)
+	fmt.Printf("  Collection:     %s\n", pocketbase.GetCollectionDisplayName(pb.Collection))
+	if pb.Identity != "" {
+		fmt.Printf("  Identity:       %s\n", pb.Identity)
+	}
+	if pb.Organization != "" {
+		fmt.Printf("  Organization:   %s\n", pb.Organization)
+	} else {
+		fmt.Printf("  Organization:   %s\n", yellow("Not Set"))
+	}
+	if pb.ImpersonatedBy != "" {
+		fmt.Printf("  Impersonated:   via context '%s'\n", pb.ImpersonatedBy)
+	}
+	if pb.TokenSource == pocketbase.EnvToken {
+		fmt.Printf("  Token:          from %s (overrides the stored session)\n", pb.TokenSource)
+	}
+	switch {
+	case pb.TokenSource == pocketbase.TokenSourceHelper:
+		fmt.Printf("  Session:        %s (%s)\n", green("Credential Helper"), pb.CredentialHelper)
+	case !pb.Authenticated:
+		fmt.Printf("  Session:        %s\n", yellow("Not Authenticated"))
+	case pb.Expired:
+		fmt.Printf("  Session:        %s (expired %s)\n", red("Expired"), pb.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	case pb.ExpiresAt != nil:
+		fmt.Printf("  Session:        %s, expires in %s (%s)\n", green("Valid"), pb.ExpiresIn,
+			pb.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	default:
+		fmt.Printf("  Session:        %s\n", green("Valid"))
+	}
+
+	nats := status.NATS
+	fmt.Printf("\n%s\n", bold("NATS:"))
+	if nats.CredentialHelper != "" {
+		fmt.Printf("  Credentials:    %s (%s)\n", green("Credential Helper"), nats.CredentialHelper)
+		return
+	}
+	fmt.Printf("  Auth Method:    %s\n", nats.AuthMethod)
+	if nats.Username != "" {
+		fmt.Printf("  Username:       %s\n", nats.Username)
+	}
+	if nats.SecretSource != "" {
+		fmt.Printf("  Secret:         from %s\n", nats.SecretSource)
+	}
+	if nats.CredsFile != "" {
+		fmt.Printf("  Creds File:     %s\n", nats.CredsFile)
+	}
+	if nats.NKeyFile != "" {
+		fmt.Printf("  NKey File:      %s\n", nats.NKeyFile)
+	}
+	if nats.PublicKey != "" {
+		fmt.Printf("  Public Key:     %s\n", nats.PublicKey)
+	}
+	switch {
+	case !nats.Configured:
+		fmt.Printf("  Credentials:    %s\n", yellow("Not Configured"))
+	case nats.CredsError != "":
+		fmt.Printf("  Credentials:    %s (%s)\n", red("Unreadable"), nats.CredsError)
+	case nats.CredsExpired:
+		fmt.Printf("  Credentials:    %s (JWT expired %s)\n", red("Expired"),
+			nats.CredsExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	case nats.CredsExpiresAt != nil:
+		fmt.Printf("  Credentials:    %s, JWT expires in %s (%s)\n", green("Configured"), nats.CredsExpiresIn,
+			nats.CredsExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	default:
+		fmt.Printf("  Credentials:    %s\n", green("Configured"))
+	}
+}
+
+// authOutputFormat validates a local --output value and quiets info messages for machine-readable output
+func authOutputFormat(format string) (string, error) {
+	format = strings.ToLower(format)
+	if err := utils.ValidateOutputFormat(format); err != nil {
+		return "", err
+	}
+	if format != config.OutputFormatTable {
+		config.Global.Quiet = true
+	}
+	return format, nil
+}
+
+func init() {
+	statusCmd.Flags().StringVarP(&statusOutputFormat, "output", "o", "table",
+		"Output format (table|json|yaml)")
+	statusCmd.Flags().BoolVar(&statusAllContexts, "all-contexts", false,
+		"Show the status of every context")
+}
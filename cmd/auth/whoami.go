@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"flint-cli/internal/config"
+	"flint-cli/internal/pocketbase"
+	"flint-cli/internal/utils"
+)
+
+var whoamiOutputFormat string
+
+// whoamiSourceContext is the token source of a session stored in the context
+const whoamiSourceContext = "context"
+
+// WhoamiResult is the identity behind the active context's session
+type WhoamiResult struct {
+	Context      string                 `json:"context" yaml:"context"`
+	Collection   string                 `json:"collection" yaml:"collection"`
+	Identity     string                 `json:"identity" yaml:"identity"`
+	Organization string                 `json:"organization,omitempty" yaml:"organization,omitempty"`
+	TokenSource  string                 `json:"token_source" yaml:"token_source"`
+	ExpiresAt    *time.Time             `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+	Record       map[string]interface{} `json:"record" yaml:"record"`
+}
+
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show who you are authenticated as",
+	Long: `Fetch the current auth record from PocketBase for the active context.
+
+Unlike 'flint auth status', this contacts the server: the session is refreshed
+(which also validates it) and the fresh auth record is stored in the context.
+Impersonation sessions and tokens supplied through FLINT_PB_TOKEN or a
+credential helper are not refreshed; their record is fetched directly.
+
+Examples:
+  flint auth whoami
+  flint --context staging auth whoami
+  flint auth whoami -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := validateActiveContext()
+		if err != nil {
+			return err
+		}
+
+		format, err := authOutputFormat(whoamiOutputFormat)
+		if err != nil {
+			return err
+		}
+
+		if !pocketbase.HasSession(ctx) {
+			return fmt.Errorf("not authenticated. Run 'flint auth pb' to authenticate")
+		}
+		if !pocketbase.CanRefreshAuth(ctx) {
+			return fmt.Errorf("authentication has expired. Run 'flint auth pb' to re-authenticate")
+		}
+
+		cmd.SilenceUsage = true
+
+		resolved := *ctx
+		resolved.PocketBase = configManager.ResolvePocketBaseConfig(ctx)
+		client := pocketbase.NewClientFromContext(&resolved)
+		if err := client.CredentialError(); err != nil {
+			return err
+		}
+		collection := ctx.PocketBase.AuthCollection
+		expires := ctx.PocketBase.AuthExpires
+		tokenSource := whoamiSourceContext
+		var record map[string]interface{}
+		switch {
+		case client.TokenSource() != "":
+			// External tokens identify their own record; nothing is saved
+			tokenSource = client.TokenSource()
+			expires = pocketbase.TokenExpiry(client.GetAuthToken())
+			claims, _ := utils.JWTClaims(client.GetAuthToken())
+			recordID, _ := claims["id"].(string)
+			collectionID, _ := claims["collectionId"].(string)
+			if recordID == "" || collectionID == "" {
+				return fmt.Errorf("token from %s is not a PocketBase auth token", tokenSource)
+			}
+			record, err = client.GetRecord(collectionID, recordID, nil)
+			if err != nil {
+				return authFailure(err)
+			}
+			if name, ok := record["collectionName"].(string); ok && name != "" {
+				collection = name
+			}
+		case ctx.PocketBase.ImpersonatedBy != "":
+			recordID, _ := ctx.PocketBase.AuthRecord["id"].(string)
+			if recordID == "" {
+				return fmt.Errorf("no authentication record available. Run 'flint auth impersonate' again")
+			}
+			record, err = client.GetRecord(collection, recordID, nil)
+			if err != nil {
+				return authFailure(err)
+			}
+		default:
+			client.EnableAutoRefresh(configManager, ctx)
+			if err := client.RefreshSession(); err != nil {
+				return authFailure(err)
+			}
+			record, err = client.GetAuthenticatedUser()
+			if err != nil {
+				return err
+			}
+			expires = ctx.PocketBase.AuthExpires
+		}
+
+		result := WhoamiResult{
+			Context:      ctx.Name,
+			Collection:   collection,
+			Identity:     recordIdentity(record),
+			Organization: ctx.PocketBase.OrganizationID,
+			TokenSource:  tokenSource,
+			ExpiresAt:    expires,
+			Record:       record,
+		}
+
+		if format != config.OutputFormatTable {
+			return utils.OutputData(result, format)
+		}
+
+		printWhoami(result, ctx)
+		return nil
+	},
+}
+
+// printWhoami prints the identity and auth record fields
+func printWhoami(result WhoamiResult, ctx *config.Context) {
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+
+	fmt.Printf("%s %s\n", green("✓"), bold(result.Identity))
+	fmt.Printf("  Collection:   %s\n", pocketbase.GetCollectionDisplayName(result.Collection))
+	if name := getRecordDisplayName(result.Record, result.Collection); name != "" && name != result.Identity {
+		fmt.Printf("  Name:         %s\n", name)
+	}
+	fmt.Printf("  Context:      %s\n", cyan(result.Context))
+	if result.Organization != "" {
+		fmt.Printf("  Organization: %s\n", result.Organization)
+	}
+	if result.TokenSource != whoamiSourceContext {
+		fmt.Printf("  Token:        from %s\n", result.TokenSource)
+	} else if ctx.PocketBase.ImpersonatedBy != "" {
+		fmt.Printf("  Impersonated: via context '%s'\n", ctx.PocketBase.ImpersonatedBy)
+	}
+	if result.ExpiresAt != nil {
+		left := time.Until(*result.ExpiresAt)
+		fmt.Printf("  Expires:      in %s (%s)\n", utils.FormatDuration(int64(left.Seconds())),
+			result.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	}
+
+	// Record fields, skipping noisy nested data
+	var fields []string
+	for field, value := range result.Record {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			continue
+		}
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	fmt.Printf("\n%s\n", bold("Auth Record:"))
+	width := 0
+	for _, field := range fields {
+		if len(field) > width {
+			width = len(field)
+		}
+	}
+	for _, field := range fields {
+		fmt.Printf("  %s%s  %v\n", field+":", strings.Repeat(" ", width-len(field)), result.Record[field])
+	}
+}
+
+func init() {
+	whoamiCmd.Flags().StringVarP(&whoamiOutputFormat, "output", "o", "table",
+		"Output format (table|json|yaml)")
+}
@@ -0,0 +1,342 @@
+package collections
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
+	"flint-cli/internal/config"
+	"flint-cli/internal/pocketbase"
+	"flint-cli/internal/utils"
+)
+
+// Matching records shown before a bulk change is confirmed
+const bulkSampleSize = 5
+
+// Failed records listed in the bulk summary
+const bulkFailureLimit = 10
+
+// Fields fetched for matching records, enough to recognise them in the sample
+var bulkSampleFields = []string{"id", "name", "email", "code"}
+
+// bulkFailure is a record a bulk change could not be applied to
+type bulkFailure struct {
+	id  string
+	err error
+}
+
+// bulkOutcome summarizes a bulk change
+type bulkOutcome struct {
+	done        int
+	failures    []bulkFailure
+	interrupted bool
+}
+
+// validateBulkOptions checks the flags shared by import and --filter changes
+func validateBulkOptions() error {
+	if workersFlag < 1 || workersFlag > 16 {
+		return fmt.Errorf("--workers must be between 1 and 16")
+	}
+	if rateFlag < 0 {
+		return fmt.Errorf("--rate cannot be negative")
+	}
+	return nil
+}
+
+// newRateLimiter returns a function that blocks until the next request may be
+// sent, shared by all workers, and a function that releases the limiter.
+// A rate of 0 means no limit.
+func newRateLimiter(rate float64) (wait func(), stop func()) {
+	if rate <= 0 {
+		return func() {}, func() {}
+	}
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	return func() { <-ticker.C }, ticker.Stop
+}
+
+// handleBulkUpdateAction applies the same update to every record matching --filter
+func handleBulkUpdateAction(ctx *config.Context, collection string, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("update with --filter takes JSON data only, not a record ID")
+	}
+	var jsonData string
+	if len(args) == 1 {
+		jsonData = args[0]
+	}
+
+	data, err := parseJSONInput(jsonData, fileFlag)
+	if err != nil {
+		return fmt.Errorf("invalid JSON input: %w", err)
+	}
+	if err := validateUpdateData(ctx, data, collection); err != nil {
+		return fmt.Errorf("invalid update data: %w", err)
+	}
+
+	return runBulkChange(ctx, collection, "update", data, func(client *pocketbase.Client, id string) error {
+		_, err := client.UpdateRecord(collection, id, data)
+		return err
+	})
+}
+
+// handleBulkDeleteAction deletes every record matching --filter
+func handleBulkDeleteAction(ctx *config.Context, collection string, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("delete with --filter does not take a record ID")
+	}
+
+	return runBulkChange(ctx, collection, "delete", nil, func(client *pocketbase.Client, id string) error {
+		return client.DeleteRecord(collection, id)
+	})
+}
+
+// runBulkChange finds the records matching --filter, asks for confirmation and
+// applies change to each of them concurrently
+func runBulkChange(ctx *config.Context, collection, action string, data map[string]interface{},
+	change func(client *pocketbase.Client, id string) error) error {
+	if strings.TrimSpace(filterFlag) == "" {
+		return fmt.Errorf("--filter cannot be empty")
+	}
+	if err := validateBulkOptions(); err != nil {
+		return err
+	}
+
+	client := createPocketBaseClient(ctx)
+
+	// Collect every match before changing anything, so updates and deletions
+	// cannot shift the pages still to be read
+	utils.PrintDebug(fmt.Sprintf("Finding records in '%s' matching: %s", collection, filterFlag))
+	ids, samples, err := findMatchingRecords(client, collection, filterFlag)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		fmt.Printf("No records in %s match the filter.\n", collection)
+		return nil
+	}
+
+	if !forceFlag {
+		if err := confirmBulkChange(collection, action, len(ids), samples, data); err != nil {
+			return err
+		}
+	}
+
+	outcome := applyBulkChange(client, ids, action, change)
+	return printBulkSummary(collection, action, len(ids), outcome)
+}
+
+// findMatchingRecords returns the IDs of all records matching filter and the
+// first few records as a sample
+func findMatchingRecords(client *pocketbase.Client, collection, filter string) ([]string, []map[string]interface{}, error) {
+	options := pocketbase.ListOptions{
+		Page:      1,
+		PerPage:   pocketbase.MaxPerPage,
+		Filter:    filter,
+		Sort:      sortFlag,
+		Fields:    bulkSampleFields,
+		SkipTotal: true,
+	}
+
+	var ids []string
+	var samples []map[string]interface{}
+	_, err := client.StreamRecords(collection, options, pocketbase.StreamOptions{}, func(record map[string]interface{}) error {
+		id, _ := record["id"].(string)
+		if id == "" {
+			return fmt.Errorf("matching record without an id")
+		}
+		ids = append(ids, id)
+		if len(samples) < bulkSampleSize {
+			samples = append(samples, record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find matching records: %s", importErrorMessage(err))
+	}
+	return ids, samples, nil
+}
+
+// confirmBulkChange shows how many records match, with a sample, and asks the
+// user to type the count to confirm
+func confirmBulkChange(collection, action string, total int, samples []map[string]interface{}, data map[string]interface{}) error {
+	red := color.New(color.FgRed).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+
+	fmt.Printf("%s %d %s to be %s:\n", red("⚠"), total, pluralRecords(total), pastTense(action))
+	fmt.Printf("  Collection: %s\n", bold(collection))
+	fmt.Printf("  Filter: %s\n", filterFlag)
+	if data != nil {
+		changes, _ := json.Marshal(data)
+		fmt.Printf("  Changes: %s\n", changes)
+	}
+
+	fmt.Println()
+	for _, record := range samples {
+		fmt.Printf("  - %s\n", describeRecord(record))
+	}
+	if total > len(samples) {
+		fmt.Printf("  ... and %d more\n", total-len(samples))
+	}
+
+	if action == "delete" {
+		showDeletionWarnings(collection)
+	}
+
+	fmt.Printf("\n%s This action cannot be undone.\n", yellow("Warning:"))
+	fmt.Printf("Type the number of records (%d) to confirm: ", total)
+
+	reader := bufio.NewReader(os.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	if strings.TrimSpace(response) != strconv.Itoa(total) {
+		noun := "Update"
+		if action == "delete" {
+			noun = "Deletion"
+		}
+		fmt.Printf("%s cancelled.\n", noun)
+		return fmt.Errorf("%s cancelled by user", strings.ToLower(noun))
+	}
+
+	return nil
+}
+
+// describeRecord returns a one-line description of a record: its ID followed by
+// the name, email and code when present
+func describeRecord(record map[string]interface{}) string {
+	parts := []string{fmt.Sprint(record["id"])}
+	for _, field := range []string{"name", "email", "code"} {
+		if value, ok := record[field].(string); ok && value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, "  ")
+}
+
+// applyBulkChange runs change for every ID on a pool of workers, sharing the
+// --rate limit, and stops feeding new IDs when the user interrupts
+func applyBulkChange(client *pocketbase.Client, ids []string, action string,
+	change func(client *pocketbase.Client, id string) error) *bulkOutcome {
+	wait, stopLimiter := newRateLimiter(rateFlag)
+	defer stopLimiter()
+
+	jobs := make(chan string, workersFlag)
+	results := make(chan bulkFailure, workersFlag)
+
+	var workers sync.WaitGroup
+	for i := 0; i < workersFlag; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for id := range jobs {
+				wait()
+				utils.PrintDebug(fmt.Sprintf("Applying %s to record '%s'", action, id))
+				results <- bulkFailure{id: id, err: change(client, id)}
+			}
+		}()
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	outcome := &bulkOutcome{}
+	go func() {
+		defer close(jobs)
+		for _, id := range ids {
+			select {
+			case jobs <- id:
+			case <-sigChan:
+				outcome.interrupted = true
+				return
+			}
+		}
+	}()
+
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	// Progress is redrawn in place on a terminal
+	showProgress := !quietFlag && term.IsTerminal(int(os.Stdout.Fd()))
+	verb := capitalize(progressTense(action))
+	for result := range results {
+		outcome.done++
+		if result.err != nil {
+			outcome.failures = append(outcome.failures, result)
+		}
+		if showProgress {
+			fmt.Printf("\r%s records: %d/%d (%d failed)", verb, outcome.done, len(ids), len(outcome.failures))
+		}
+	}
+	if showProgress {
+		fmt.Println()
+	}
+
+	return outcome
+}
+
+// printBulkSummary reports the outcome of a bulk change and returns an error
+// when any record failed or the change was interrupted
+func printBulkSummary(collection, action string, total int, outcome *bulkOutcome) error {
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	failed := len(outcome.failures)
+	succeeded := outcome.done - failed
+
+	if failed == 0 && !outcome.interrupted {
+		if !quietFlag {
+			fmt.Printf("%s %s %d %s in %s\n", green("✓"), capitalize(pastTense(action)), succeeded, pluralRecords(succeeded), collection)
+		}
+		return nil
+	}
+
+	fmt.Printf("%s %s %d of %d %s in %s, %d failed\n", red("✗"), capitalize(pastTense(action)),
+		succeeded, total, pluralRecords(total), collection, failed)
+	for i, failure := range outcome.failures {
+		if i == bulkFailureLimit {
+			fmt.Printf("  ... and %d more\n", failed-bulkFailureLimit)
+			break
+		}
+		// Indent multi-line validation messages under their record
+		message := strings.ReplaceAll(importErrorMessage(failure.err), "\n", "\n    ")
+		fmt.Printf("  - %s: %s\n", failure.id, message)
+	}
+
+	if outcome.interrupted {
+		return fmt.Errorf("%s interrupted after %d of %d records", action, outcome.done, total)
+	}
+	return fmt.Errorf("failed to %s %d of %d records", action, failed, total)
+}
+
+// pastTense returns "updated" or "deleted" for an action
+func pastTense(action string) string {
+	return action + "d"
+}
+
+// progressTense returns "updating" or "deleting" for an action
+func progressTense(action string) string {
+	return strings.TrimSuffix(action, "e") + "ing"
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,321 @@
+package collections
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"flint-cli/internal/config"
+	"flint-cli/internal/pocketbase"
+	"flint-cli/internal/utils"
+)
+
+// Columns of the collections overview, in order
+var overviewColumns = []string{"name", "type", "system", "fields", "in_context"}
+
+// Field options shown as flags or in the type column rather than as options
+var flagOptions = map[string]bool{
+	"collectionId": true,
+	"hidden":       true,
+	"presentable":  true,
+	"primaryKey":   true,
+	"system":       true,
+}
+
+// handleCollectionsOverview lists every collection on the server and whether
+// the active context makes it available
+func handleCollectionsOverview() error {
+	ctx, err := validateActiveContext()
+	if err != nil {
+		return err
+	}
+
+	collections, err := fetchCollections(ctx)
+	if err != nil {
+		return err
+	}
+
+	available := make(map[string]bool)
+	for _, name := range ctx.PocketBase.AvailableCollections {
+		available[name] = true
+	}
+
+	// Regular collections first, then system collections, each by name
+	sort.SliceStable(collections, func(i, j int) bool {
+		if collections[i].System != collections[j].System {
+			return !collections[i].System
+		}
+		return collections[i].Name < collections[j].Name
+	})
+
+	records := make([]map[string]interface{}, 0, len(collections))
+	onServer := make(map[string]bool)
+	for _, collection := range collections {
+		onServer[collection.Name] = true
+		records = append(records, map[string]interface{}{
+			"name":       collection.Name,
+			"type":       collection.Type,
+			"system":     collection.System,
+			"fields":     len(collection.SchemaFields()),
+			"in_context": available[collection.Name],
+		})
+	}
+
+	if outputFlag != "" && outputFlag != config.OutputFormatTable {
+		if err := utils.ValidateOutputFormat(outputFlag); err != nil {
+			return err
+		}
+		if utils.IsRecordFormat(outputFlag) {
+			return utils.OutputRecords(os.Stdout, records, outputFlag, overviewColumns)
+		}
+		return utils.OutputData(records, outputFlag)
+	}
+
+	for _, record := range records {
+		record["system"] = yesNo(record["system"].(bool))
+		record["in_context"] = yesNo(record["in_context"].(bool))
+	}
+	if err := utils.RenderTable(os.Stdout, records, utils.TableOptions{
+		Columns: overviewColumns,
+	}); err != nil {
+		return err
+	}
+
+	// Collections the context lists that the server does not have
+	var missing []string
+	for _, name := range ctx.PocketBase.AvailableCollections {
+		if !onServer[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		utils.PrintWarning(fmt.Sprintf("available_collections lists collections the server does not have: %s", strings.Join(missing, ", ")))
+	}
+
+	return nil
+}
+
+// handleDescribeAction shows a collection's fields, indexes and API rules
+func handleDescribeAction(ctx *config.Context, collection string, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("describe does not take arguments")
+	}
+
+	collections, err := fetchCollections(ctx)
+	if err != nil {
+		return err
+	}
+
+	schema := pocketbase.FindCollection(collections, collection)
+	if schema == nil {
+		return fmt.Errorf("collection '%s' not found on the server", collection)
+	}
+
+	if outputFlag != "" && outputFlag != config.OutputFormatTable {
+		if err := utils.ValidateOutputFormat(outputFlag); err != nil {
+			return err
+		}
+		data, err := collectionData(schema)
+		if err != nil {
+			return err
+		}
+		return utils.OutputData(data, outputFlag)
+	}
+
+	return displayCollectionSchema(schema, collections)
+}
+
+// fetchCollections returns the collection definitions from the server
+func fetchCollections(ctx *config.Context) ([]pocketbase.Collection, error) {
+	client := createPocketBaseClient(ctx)
+
+	collections, err := client.GetCollections()
+	if err != nil {
+		var pbErr *pocketbase.PocketBaseError
+		if errors.As(err, &pbErr) && pbErr.StatusCode == http.StatusForbidden {
+			return nil, fmt.Errorf("%w. Authenticate as a superuser with 'flint auth pb'", pocketbase.ErrSchemaForbidden)
+		}
+		return nil, fmt.Errorf("failed to get collections: %s", importErrorMessage(err))
+	}
+	return collections, nil
+}
+
+// collectionData converts a collection definition to plain data, so YAML and
+// delimited output use the PocketBase field names
+func collectionData(collection *pocketbase.Collection) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(collection)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// displayCollectionSchema prints a collection's fields, indexes and API rules
+func displayCollectionSchema(collection *pocketbase.Collection, collections []pocketbase.Collection) error {
+	bold := color.New(color.Bold).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	fmt.Printf("%s %s (%s)\n", bold("Collection:"), cyan(collection.Name), collection.Type)
+	fmt.Printf("  ID: %s\n", collection.ID)
+	if collection.System {
+		fmt.Printf("  System collection\n")
+	}
+
+	fmt.Printf("\n%s\n", bold("Fields:"))
+	fields := collection.SchemaFields()
+	records := make([]map[string]interface{}, 0, len(fields))
+	for _, field := range fields {
+		records = append(records, map[string]interface{}{
+			"name":    field.Name,
+			"type":    fieldType(field, collections),
+			"flags":   strings.Join(fieldFlags(field), ", "),
+			"options": fieldOptions(field),
+		})
+	}
+	if err := utils.RenderTable(os.Stdout, records, utils.TableOptions{
+		Columns: []string{"name", "type", "flags", "options"},
+		Wide:    true,
+	}); err != nil {
+		return err
+	}
+
+	if len(collection.Indexes) > 0 {
+		fmt.Printf("\n%s\n", bold("Indexes:"))
+		for _, index := range collection.Indexes {
+			fmt.Printf("  %s\n", index)
+		}
+	}
+
+	fmt.Printf("\n%s\n", bold("API Rules:"))
+	rules := []struct {
+		name string
+		rule *string
+	}{
+		{"List", collection.ListRule},
+		{"View", collection.ViewRule},
+		{"Create", collection.CreateRule},
+		{"Update", collection.UpdateRule},
+		{"Delete", collection.DeleteRule},
+	}
+	for _, rule := range rules {
+		fmt.Printf("  %-7s %s\n", rule.name+":", describeRule(rule.rule))
+	}
+
+	return nil
+}
+
+// fieldType returns a field's type, with the target collection for relations
+func fieldType(field pocketbase.Field, collections []pocketbase.Collection) string {
+	if field.Type != "relation" {
+		return field.Type
+	}
+
+	target, _ := field.Options["collectionId"].(string)
+	if collection := pocketbase.FindCollection(collections, target); collection != nil {
+		target = collection.Name
+	}
+	if target == "" {
+		return field.Type
+	}
+	return fmt.Sprintf("relation → %s", target)
+}
+
+// fieldFlags returns the flags set on a field (required, unique, ...)
+func fieldFlags(field pocketbase.Field) []string {
+	var flags []string
+	if field.Required {
+		flags = append(flags, "required")
+	}
+	if field.Unique {
+		flags = append(flags, "unique")
+	}
+	if primary, _ := field.Options["primaryKey"].(bool); primary {
+		flags = append(flags, "primary key")
+	}
+	if field.System {
+		flags = append(flags, "system")
+	}
+	if hidden, _ := field.Options["hidden"].(bool); hidden {
+		flags = append(flags, "hidden")
+	}
+	if field.Presentable {
+		flags = append(flags, "presentable")
+	}
+	return flags
+}
+
+// fieldOptions formats the options that are set on a field, sorted by name
+func fieldOptions(field pocketbase.Field) string {
+	keys := make([]string, 0, len(field.Options))
+	for key := range field.Options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var options []string
+	for _, key := range keys {
+		if flagOptions[key] {
+			continue
+		}
+		if value := optionValue(field.Options[key], field.Type == "number"); value != "" {
+			options = append(options, fmt.Sprintf("%s: %s", key, value))
+		}
+	}
+	return strings.Join(options, "; ")
+}
+
+// optionValue formats a field option, or returns "" for options that are not
+// set. Zero only counts as set for number limits.
+func optionValue(value interface{}, keepZero bool) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case bool:
+		if !v {
+			return ""
+		}
+		return "true"
+	case float64:
+		if v == 0 && !keepZero {
+			return ""
+		}
+	case string:
+		return v
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		return strings.Join(items, ", ")
+	}
+	return utils.FormatCell(value)
+}
+
+// describeRule explains an API rule: nil locks the action to superusers and
+// an empty rule allows everyone
+func describeRule(rule *string) string {
+	switch {
+	case rule == nil:
+		return "superusers only"
+	case *rule == "":
+		return "anyone"
+	}
+	return *rule
+}
+
+// yesNo formats a boolean for tables
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
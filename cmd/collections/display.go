@@ -2,6 +2,8 @@ package collections
 
 import (
 	"fmt"
+	"os"
+	"sort"
 	"strings"
 
 	"flint-cli/internal/config"
@@ -9,25 +11,40 @@ import (
 	"flint-cli/internal/utils"
 )
 
-// displayListTable displays the results in a user-friendly table format
-func displayListTable(result *pocketbase.RecordsList, collection string) error {
+// displayListTable displays the results in a user-friendly table format, with
+// the columns in schema order when the schema is known
+func displayListTable(result *pocketbase.RecordsList, collection string, fieldOrder []string) error {
 	if result == nil || len(result.Items) == 0 {
 		fmt.Printf("No %s found.\n", collection)
 		return nil
 	}
 
-	// Show pagination info
-	fmt.Printf("%s (%d-%d of %d total)\n\n", 
-		utils.TitleCase(collection),
-		((result.Page-1)*result.PerPage)+1,
-		min(result.Page*result.PerPage, result.TotalItems),
-		result.TotalItems)
+	// Show pagination info (totals are -1 when the count was skipped)
+	first := ((result.Page-1)*result.PerPage)+1
+	if result.TotalItems < 0 {
+		fmt.Printf("%s (%d-%d)\n\n", utils.TitleCase(collection), first, first+len(result.Items)-1)
+	} else {
+		fmt.Printf("%s (%d-%d of %d total)\n\n", 
+			utils.TitleCase(collection),
+			first,
+			min(result.Page*result.PerPage, result.TotalItems),
+			result.TotalItems)
+	}
 
 	// Display table
-	if err := utils.OutputData(result.Items, config.OutputFormatTable); err != nil {
+	if err := utils.RenderTable(os.Stdout, result.Items, listTableOptions(collection, fieldOrder)); err != nil {
 		return fmt.Errorf("failed to display table: %w", err)
 	}
 
+	// Without a total, a full page means there may be another one
+	if result.TotalPages < 0 {
+		if len(result.Items) == result.PerPage {
+			fmt.Printf("\nPagination:\n")
+			fmt.Printf("  Next: --offset %d\n", result.Page*result.PerPage)
+		}
+		return nil
+	}
+
 	// Show pagination navigation hints
 	if result.TotalPages > 1 {
 		fmt.Printf("\nPagination:\n")
@@ -46,6 +63,25 @@ func displayListTable(result *pocketbase.RecordsList, collection string) error {
 	return nil
 }
 
+// listTableOptions returns how a collection's records are rendered as a table.
+// Collections with specific fields default to those columns; --wide shows all.
+func listTableOptions(collection string, fieldOrder []string) utils.TableOptions {
+	options := utils.TableOptions{
+		Columns:    columnsFlag,
+		FieldOrder: fieldOrder,
+		Wide:       wideFlag,
+		MaxWidth:   utils.TerminalWidth(),
+	}
+
+	// With --fields the server already returns just the wanted fields
+	if specific := getCollectionSpecificFields(collection); len(specific) > 0 && len(fieldsFlag) == 0 {
+		options.Defaults = append([]string{"email", "code"}, specific...)
+		options.Defaults = append(options.Defaults, "updated")
+	}
+
+	return options
+}
+
 // displayGetTable displays a single record in table format
 func displayGetTable(record map[string]interface{}, collection, recordID string) error {
 	if record == nil {
@@ -106,8 +142,14 @@ func displayRecordDetails(record map[string]interface{}, collection string) erro
 		skipFields[field] = true
 	}
 
-	for key, value := range record {
-		if !skipFields[key] && value != nil {
+	keys := make([]string, 0, len(record))
+	for key := range record {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if value := record[key]; !skipFields[key] && value != nil && key != "expand" {
 			fmt.Printf("  %s: %v\n", utils.TitleCase(key), value)
 		}
 	}
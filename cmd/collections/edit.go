@@ -0,0 +1,335 @@
+package collections
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
+	"flint-cli/internal/config"
+	"flint-cli/internal/pocketbase"
+	"flint-cli/internal/utils"
+)
+
+// Fields left out of the edited document because PocketBase manages them
+var editManagedFields = map[string]bool{
+	"id":             true,
+	"collectionId":   true,
+	"collectionName": true,
+	"created":        true,
+	"updated":        true,
+	"expand":         true,
+}
+
+// handleEditAction opens a record in $EDITOR and sends the changed fields
+func handleEditAction(ctx *config.Context, collection string, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("edit requires exactly one record ID argument")
+	}
+
+	recordID := args[0]
+	if strings.TrimSpace(recordID) == "" {
+		return fmt.Errorf("record ID cannot be empty")
+	}
+
+	// The record is edited as YAML unless JSON is asked for
+	format := config.OutputFormatYAML
+	if strings.ToLower(outputFlag) == config.OutputFormatJSON {
+		format = config.OutputFormatJSON
+	}
+
+	client := createPocketBaseClient(ctx)
+
+	record, err := client.GetRecord(collection, recordID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve record: %s", importErrorMessage(err))
+	}
+
+	original := make(map[string]interface{})
+	for key, value := range record {
+		if !editManagedFields[key] {
+			original[key] = value
+		}
+	}
+
+	body, err := encodeEditDocument(original, schemaFieldOrder(ctx, collection), format)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", "flint-edit-*."+format)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	path := file.Name()
+	file.Close()
+
+	// Edit until the changes are accepted, the user gives up, or an error
+	// other than invalid input occurs
+	var problem error
+	for {
+		header := editHeader(collection, recordID, problem)
+		if err := os.WriteFile(path, append([]byte(header), body...), 0600); err != nil {
+			return fmt.Errorf("failed to write temporary file: %w", err)
+		}
+
+		if err := runEditor(path); err != nil {
+			return fmt.Errorf("%w. Your changes are in %s", err, path)
+		}
+
+		edited, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read edited record: %w", err)
+		}
+		body = stripEditComments(edited)
+
+		if len(bytes.TrimSpace(body)) == 0 {
+			os.Remove(path)
+			fmt.Println("Edit cancelled, the file was empty.")
+			return nil
+		}
+
+		updated, err := decodeEditDocument(body, format)
+		if err != nil {
+			problem = err
+			continue
+		}
+
+		changes := changedFields(original, updated)
+		if len(changes) == 0 {
+			os.Remove(path)
+			fmt.Println("Edit cancelled, no changes made.")
+			return nil
+		}
+
+		if err := validateUpdateData(ctx, changes, collection); err != nil {
+			problem = err
+			continue
+		}
+
+		printRecordDiff(original, changes)
+
+		utils.PrintDebug(fmt.Sprintf("Updating record '%s' in collection '%s' with data: %+v", recordID, collection, changes))
+		result, err := client.UpdateRecord(collection, recordID, changes)
+		if err != nil {
+			// Rejected input goes back to the editor; anything else stops here
+			var pbErr *pocketbase.PocketBaseError
+			if errors.As(err, &pbErr) && pbErr.StatusCode == 400 {
+				problem = err
+				continue
+			}
+			return fmt.Errorf("failed to update record: %s. Your changes are in %s", importErrorMessage(err), path)
+		}
+
+		os.Remove(path)
+
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("\n%s Record updated successfully!\n", green("✓"))
+		fmt.Printf("  Record ID: %s\n", recordID)
+		fmt.Printf("  Collection: %s\n", collection)
+		if name, ok := result["name"].(string); ok && name != "" {
+			fmt.Printf("  Name: %s\n", name)
+		}
+		fmt.Printf("  Updated %d field(s)\n", len(changes))
+		return nil
+	}
+}
+
+// encodeEditDocument writes a record as YAML or indented JSON with its fields
+// in schema order, followed by any other fields sorted by name
+func encodeEditDocument(record map[string]interface{}, order []string, format string) ([]byte, error) {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range order {
+		if _, ok := record[key]; ok && !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	for _, key := range sortedRecordKeys(record) {
+		if !seen[key] {
+			keys = append(keys, key)
+		}
+	}
+
+	var buf bytes.Buffer
+	if format == config.OutputFormatJSON {
+		buf.WriteString("{\n")
+		for i, key := range keys {
+			name, _ := json.Marshal(key)
+			value, err := json.MarshalIndent(record[key], "  ", "  ")
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode field '%s': %w", key, err)
+			}
+			separator := ","
+			if i == len(keys)-1 {
+				separator = ""
+			}
+			fmt.Fprintf(&buf, "  %s: %s%s\n", name, value, separator)
+		}
+		buf.WriteString("}\n")
+		return buf.Bytes(), nil
+	}
+
+	document := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range keys {
+		var value yaml.Node
+		if err := value.Encode(record[key]); err != nil {
+			return nil, fmt.Errorf("failed to encode field '%s': %w", key, err)
+		}
+		document.Content = append(document.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, &value)
+	}
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return nil, fmt.Errorf("failed to encode record: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode record: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeEditDocument parses the edited record. Values are normalized through
+// JSON so they compare equal to the record as PocketBase returned it.
+func decodeEditDocument(body []byte, format string) (map[string]interface{}, error) {
+	var parsed interface{}
+	if format == config.OutputFormatJSON {
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	} else {
+		if err := yaml.Unmarshal(body, &parsed); err != nil {
+			return nil, fmt.Errorf("invalid YAML: %w", err)
+		}
+	}
+
+	if _, ok := parsed.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("the record must be a mapping of field names to values")
+	}
+
+	normalized, err := json.Marshal(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported value in the record: %w", err)
+	}
+	var record map[string]interface{}
+	if err := json.Unmarshal(normalized, &record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// changedFields returns the fields whose value differs from the original.
+// A field removed from the document is cleared (sent as null).
+func changedFields(original, updated map[string]interface{}) map[string]interface{} {
+	changes := make(map[string]interface{})
+	for key, value := range updated {
+		if previous, ok := original[key]; !ok || !reflect.DeepEqual(previous, value) {
+			changes[key] = value
+		}
+	}
+	for key := range original {
+		if _, ok := updated[key]; !ok {
+			changes[key] = nil
+		}
+	}
+	return changes
+}
+
+// printRecordDiff shows the changed fields, old values in red and new values in green
+func printRecordDiff(original, changes map[string]interface{}) {
+	red := color.New(color.FgRed).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+
+	fmt.Println("Changes:")
+	for _, key := range sortedRecordKeys(changes) {
+		if previous, ok := original[key]; ok {
+			fmt.Println(red(fmt.Sprintf("- %s: %s", key, diffValue(previous))))
+		}
+		if changes[key] != nil {
+			fmt.Println(green(fmt.Sprintf("+ %s: %s", key, diffValue(changes[key]))))
+		}
+	}
+}
+
+// diffValue formats a field value on one line for the diff
+func diffValue(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// editHeader returns the comment block above the edited record, including the
+// problem with the previous attempt
+func editHeader(collection, recordID string, problem error) string {
+	var header strings.Builder
+	fmt.Fprintf(&header, "# Editing %s/%s. This comment block is ignored,\n", collection, recordID)
+	header.WriteString("# and an empty file cancels the edit. Only changed fields are sent.\n")
+	header.WriteString("# Remove a field to clear it.\n")
+
+	if problem != nil {
+		header.WriteString("#\n# The changes could not be applied:\n")
+		for _, line := range strings.Split(importErrorMessage(problem), "\n") {
+			fmt.Fprintf(&header, "#   %s\n", line)
+		}
+	}
+
+	header.WriteString("#\n")
+	return header.String()
+}
+
+// stripEditComments removes the comment header flint wrote above the record.
+// Other '#' lines are left alone: in YAML they are comments or part of a block
+// scalar, such as a markdown heading, and the parser tells them apart.
+func stripEditComments(content []byte) []byte {
+	lines := bytes.Split(content, []byte("\n"))
+	start := 0
+	for start < len(lines) && bytes.HasPrefix(bytes.TrimSpace(lines[start]), []byte("#")) {
+		start++
+	}
+	return bytes.Join(lines[start:], []byte("\n"))
+}
+
+// runEditor opens path in $VISUAL or $EDITOR (vi, or notepad on Windows)
+func runEditor(path string) error {
+	editor := []string{"vi"}
+	if runtime.GOOS == "windows" {
+		editor = []string{"notepad"}
+	}
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			editor = fields
+			break
+		}
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", editor[0], err)
+	}
+	return nil
+}
+
+// sortedRecordKeys returns the keys of a record in sorted order
+func sortedRecordKeys(record map[string]interface{}) []string {
+	keys := make([]string, 0, len(record))
+	for key := range record {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
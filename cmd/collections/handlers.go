@@ -2,6 +2,7 @@ package collections
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -20,17 +21,26 @@ func handleListAction(ctx *config.Context, collection string, args []string) err
 		return fmt.Errorf("list action does not accept positional arguments, use flags instead")
 	}
 
+	// Walking pages streams the records instead of showing a single page
+	if allFlag || maxFlag > 0 {
+		return handleListAllAction(ctx, collection)
+	}
+	if concurrencyFlag != 1 {
+		return fmt.Errorf("--concurrency requires --all or --max")
+	}
+
 	// Create PocketBase client
 	client := createPocketBaseClient(ctx)
 
 	// Build list options from global flags
 	options := &pocketbase.ListOptions{
-		Page:    calculatePage(offsetFlag, limitFlag),
-		PerPage: limitFlag,
-		Filter:  filterFlag,
-		Sort:    sortFlag,
-		Fields:  fieldsFlag,
-		Expand:  expandFlag,
+		Page:      calculatePage(offsetFlag, limitFlag),
+		PerPage:   limitFlag,
+		Filter:    filterFlag,
+		Sort:      sortFlag,
+		Fields:    fieldsFlag,
+		Expand:    expandFlag,
+		SkipTotal: skipTotalFlag,
 	}
 
 	// Validate pagination parameters
@@ -66,12 +76,109 @@ func handleListAction(ctx *config.Context, collection string, args []string) err
 	case config.OutputFormatYAML:
 		return utils.OutputData(result, config.OutputFormatYAML) 
 	case config.OutputFormatTable:
-		return displayListTable(result, collection)
+		return displayListTable(result, collection, schemaFieldOrder(ctx, collection))
+	case config.OutputFormatNDJSON, config.OutputFormatCSV, config.OutputFormatTSV:
+		return utils.OutputRecords(os.Stdout, result.Items, outputFormat, fieldsFlag)
 	default:
 		return fmt.Errorf("unsupported output format: %s", outputFormat)
 	}
 }
 
+// handleListAllAction walks the pages of a list query and streams every record
+// to the output, so whole collections can be exported without holding them in memory
+func handleListAllAction(ctx *config.Context, collection string) error {
+	if maxFlag < 0 {
+		return fmt.Errorf("--max must be a positive number")
+	}
+	if offsetFlag != 0 {
+		return fmt.Errorf("--offset cannot be combined with --all or --max")
+	}
+	if concurrencyFlag < 1 || concurrencyFlag > 10 {
+		return fmt.Errorf("--concurrency must be between 1 and 10")
+	}
+
+	options := pocketbase.ListOptions{
+		Page:      1,
+		PerPage:   limitFlag,
+		Filter:    filterFlag,
+		Sort:      sortFlag,
+		Fields:    fieldsFlag,
+		Expand:    expandFlag,
+		SkipTotal: skipTotalFlag,
+	}
+	if err := validatePaginationOptions(&options); err != nil {
+		return fmt.Errorf("invalid pagination options: %w", err)
+	}
+
+	outputFormat := outputFlag
+	if outputFormat == "" {
+		outputFormat = config.Global.OutputFormat
+	}
+
+	stream, err := utils.NewRecordStream(os.Stdout, outputFormat, fieldsFlag)
+	if err != nil {
+		return err
+	}
+	stream.SetTableOptions(listTableOptions(collection, nil))
+
+	// Table, csv and tsv columns are fixed before every record is seen, so
+	// they follow the schema rather than the fields of the first page
+	if len(fieldsFlag) == 0 && isColumnFormat(outputFormat) {
+		stream.SetFieldOrder(schemaFieldOrder(ctx, collection))
+		stream.SetExpand(expandFlag)
+	}
+
+	client := createPocketBaseClient(ctx)
+
+	utils.PrintDebug(fmt.Sprintf("Streaming records from collection '%s': perPage=%d, max=%d, concurrency=%d, skipTotal=%t, filter='%s', sort='%s'",
+		collection, options.PerPage, maxFlag, concurrencyFlag, options.SkipTotal, options.Filter, options.Sort))
+
+	_, streamErr := client.StreamRecords(collection, options, pocketbase.StreamOptions{
+		Max:         maxFlag,
+		Concurrency: concurrencyFlag,
+	}, stream.Write)
+
+	// Close the output even on failure so the records already written stay well-formed
+	if err := stream.Close(); err != nil && streamErr == nil {
+		streamErr = err
+	}
+
+	if streamErr != nil {
+		var pbErr *pocketbase.PocketBaseError
+		if errors.As(streamErr, &pbErr) {
+			utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+			if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+				fmt.Fprintf(os.Stderr, "\nSuggestion: %s\n", suggestion)
+			}
+			return fmt.Errorf("listing stopped after %d %s", stream.Count(), pluralRecords(stream.Count()))
+		}
+		return fmt.Errorf("listing stopped after %d %s: %w", stream.Count(), pluralRecords(stream.Count()), streamErr)
+	}
+
+	if outputFormat == config.OutputFormatTable && stream.Count() > 0 {
+		fmt.Printf("\n%d %s listed\n", stream.Count(), pluralRecords(stream.Count()))
+	}
+
+	return nil
+}
+
+// isColumnFormat reports whether an output format lays records out in columns
+func isColumnFormat(format string) bool {
+	switch strings.ToLower(format) {
+	case config.OutputFormatTable, config.OutputFormatCSV, config.OutputFormatTSV:
+		return true
+	}
+	return false
+}
+
+// pluralRecords returns "record" or "records" for n
+func pluralRecords(n int) string {
+	if n == 1 {
+		return "record"
+	}
+	return "records"
+}
+
 // handleGetAction handles the get action for a collection
 func handleGetAction(ctx *config.Context, collection string, args []string) error {
 	// Expect exactly one positional argument (record ID)
@@ -118,6 +225,8 @@ func handleGetAction(ctx *config.Context, collection string, args []string) erro
 		return utils.OutputData(record, config.OutputFormatYAML)
 	case config.OutputFormatTable:
 		return displayGetTable(record, collection, recordID)
+	case config.OutputFormatNDJSON, config.OutputFormatCSV, config.OutputFormatTSV:
+		return utils.OutputRecords(os.Stdout, []map[string]interface{}{record}, outputFormat, nil)
 	default:
 		return fmt.Errorf("unsupported output format: %s", outputFormat)
 	}
@@ -138,7 +247,7 @@ func handleCreateAction(ctx *config.Context, collection string, args []string) e
 	}
 
 	// Validate that we don't have restricted fields
-	if err := validateCreateData(data, collection); err != nil {
+	if err := validateCreateData(ctx, data, collection); err != nil {
 		return fmt.Errorf("invalid create data: %w", err)
 	}
 
@@ -200,9 +309,13 @@ func handleCreateAction(ctx *config.Context, collection string, args []string) e
 
 // handleUpdateAction handles the update action for a collection
 func handleUpdateAction(ctx *config.Context, collection string, args []string) error {
+	if filterFlag != "" {
+		return handleBulkUpdateAction(ctx, collection, args)
+	}
+
 	// Expect at least record ID, optionally JSON data
 	if len(args) < 1 {
-		return fmt.Errorf("update requires a record ID argument")
+		return fmt.Errorf("update requires a record ID argument (or --filter to update every match)")
 	}
 	
 	recordID := args[0]
@@ -224,7 +337,7 @@ func handleUpdateAction(ctx *config.Context, collection string, args []string) e
 	}
 
 	// Validate that we don't have restricted fields
-	if err := validateUpdateData(data, collection); err != nil {
+	if err := validateUpdateData(ctx, data, collection); err != nil {
 		return fmt.Errorf("invalid update data: %w", err)
 	}
 
@@ -285,9 +398,13 @@ func handleUpdateAction(ctx *config.Context, collection string, args []string) e
 
 // handleDeleteAction handles the delete action for a collection
 func handleDeleteAction(ctx *config.Context, collection string, args []string) error {
+	if filterFlag != "" {
+		return handleBulkDeleteAction(ctx, collection, args)
+	}
+
 	// Expect exactly one positional argument (record ID)
 	if len(args) != 1 {
-		return fmt.Errorf("delete requires exactly one record ID argument")
+		return fmt.Errorf("delete requires exactly one record ID argument (or --filter to delete every match)")
 	}
 	
 	recordID := args[0]
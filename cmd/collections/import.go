@@ -0,0 +1,688 @@
+package collections
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"flint-cli/internal/config"
+	"flint-cli/internal/pocketbase"
+	"flint-cli/internal/utils"
+)
+
+// Input formats accepted by the import action
+const (
+	importFormatCSV    = "csv"
+	importFormatTSV    = "tsv"
+	importFormatNDJSON = "ndjson"
+	importFormatJSON   = "json"
+)
+
+// Fields PocketBase manages itself, dropped from imported rows so exports can be
+// re-imported. The id can still serve as the upsert key.
+var importManagedFields = []string{"id", "collectionId", "collectionName", "created", "updated", "expand"}
+
+// checkpointInterval is how often the checkpoint is saved while importing
+const checkpointInterval = time.Second
+
+// importRow is one input record with its 1-based row number
+type importRow struct {
+	number int
+	data   map[string]interface{}
+	key    interface{} // Value of the upsert key, if one is set
+	err    error       // Row-level parse error
+}
+
+// importReader yields input rows until io.EOF
+type importReader interface {
+	Next() (*importRow, error)
+}
+
+// importResult is the outcome of importing one row
+type importResult struct {
+	row    *importRow
+	action string // "created", "updated" or, in a dry run, "valid"
+	err    error
+}
+
+// importCheckpoint records how far an import got so it can be resumed
+type importCheckpoint struct {
+	File       string    `json:"file"`
+	Collection string    `json:"collection"`
+	NextRow    int       `json:"next_row"`
+	Updated    time.Time `json:"updated"`
+}
+
+// importErrorEntry is one line of the per-row error report
+type importErrorEntry struct {
+	Row   int                    `json:"row"`
+	Error string                 `json:"error"`
+	Data  map[string]interface{} `json:"data,omitempty"`
+}
+
+// handleImportAction imports records from a CSV, TSV, NDJSON or JSON array file
+func handleImportAction(ctx *config.Context, collection string, args []string) error {
+	path := fileFlag
+	if len(args) > 0 {
+		if path != "" {
+			return fmt.Errorf("cannot specify both a file argument and --file")
+		}
+		path = args[0]
+	}
+	if path == "" || len(args) > 1 {
+		return fmt.Errorf("import requires exactly one file argument")
+	}
+
+	if err := validateBulkOptions(); err != nil {
+		return err
+	}
+
+	mapping, err := parseImportMapping(importMapFlag)
+	if err != nil {
+		return err
+	}
+
+	format, err := detectImportFormat(path, importFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	checkpointPath := importCheckpointFlag
+	if checkpointPath == "" {
+		checkpointPath = path + ".checkpoint"
+	}
+	errorsPath := importErrorsFlag
+	if errorsPath == "" {
+		errorsPath = path + ".errors.ndjson"
+	}
+
+	// Continue after the rows a previous run finished
+	startRow := 1
+	if importResumeFlag {
+		checkpoint, err := loadImportCheckpoint(checkpointPath)
+		if err != nil {
+			return err
+		}
+		if checkpoint.Collection != collection || checkpoint.File != absPath {
+			return fmt.Errorf("checkpoint %s is for importing %s into '%s'", checkpointPath, checkpoint.File, checkpoint.Collection)
+		}
+		startRow = checkpoint.NextRow
+	} else if _, err := os.Stat(checkpointPath); err == nil && !importDryRunFlag {
+		utils.PrintWarning(fmt.Sprintf("Found checkpoint %s from an earlier run; starting from the first row (use --resume to continue it)", checkpointPath))
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	reader, err := newImportReader(file, format)
+	if err != nil {
+		return err
+	}
+
+	client := createPocketBaseClient(ctx)
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	mode := ""
+	if importDryRunFlag {
+		mode = " (dry run, nothing will be written)"
+	}
+	fmt.Printf("Importing %s into %s%s\n", cyan(path), cyan(collection), mode)
+	if importUpsertKeyFlag != "" {
+		fmt.Printf("  Upsert key: %s\n", importUpsertKeyFlag)
+	}
+	if startRow > 1 {
+		fmt.Printf("  Resuming at row %d\n", startRow)
+	}
+
+	report := &importReport{path: errorsPath, appendMode: importResumeFlag}
+	defer report.close()
+
+	progress := &importProgress{next: startRow, done: make(map[int]bool)}
+	saveCheckpoint := func() {
+		if importDryRunFlag {
+			return
+		}
+		checkpoint := importCheckpoint{File: absPath, Collection: collection, NextRow: progress.next, Updated: time.Now()}
+		if err := saveImportCheckpoint(checkpointPath, checkpoint); err != nil {
+			utils.PrintWarning(err.Error())
+		}
+	}
+
+	// Requests from all workers share one rate limit
+	wait, stopLimiter := newRateLimiter(rateFlag)
+	defer stopLimiter()
+
+	jobs := make(chan *importRow, workersFlag)
+	results := make(chan importResult, workersFlag)
+
+	var workers sync.WaitGroup
+	for i := 0; i < workersFlag; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for row := range jobs {
+				action, err := importRecord(ctx, client, collection, row.data, row.key, wait)
+				results <- importResult{row: row, action: action, err: err}
+			}
+		}()
+	}
+
+	// Feed rows to the workers until the input ends, a read fails or the user interrupts
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	var readErr error
+	interrupted := false
+	go func() {
+		defer close(jobs)
+		for {
+			row, err := reader.Next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				readErr = err
+				return
+			}
+			if row.number < startRow {
+				continue
+			}
+
+			if row.err == nil {
+				row.data, row.key = mapImportFields(row.data, mapping)
+				// Upsert rows are validated by the workers once the lookup
+				// shows whether they create or update a record
+				if importUpsertKeyFlag == "" {
+					row.err = validateCreateData(ctx, row.data, collection)
+				}
+			}
+			if row.err != nil {
+				results <- importResult{row: row, err: row.err}
+				continue
+			}
+
+			select {
+			case jobs <- row:
+			case <-sigChan:
+				interrupted = true
+				return
+			}
+		}
+	}()
+
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	counts := make(map[string]int)
+	succeeded, failed := 0, 0
+	lastSave := time.Now()
+	for result := range results {
+		if result.err != nil {
+			failed++
+			utils.PrintWarning(fmt.Sprintf("row %d: %v", result.row.number, importErrorMessage(result.err)))
+			if err := report.write(result.row, importErrorMessage(result.err)); err != nil {
+				utils.PrintWarning(err.Error())
+			}
+		} else {
+			counts[result.action]++
+			succeeded++
+		}
+
+		progress.complete(result.row.number)
+		if time.Since(lastSave) >= checkpointInterval {
+			saveCheckpoint()
+			lastSave = time.Now()
+		}
+	}
+
+	// Summary
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	fmt.Println()
+	if importDryRunFlag {
+		fmt.Printf("%s Dry run finished: %d valid, %d invalid\n", green("✓"), succeeded, failed)
+		if importUpsertKeyFlag != "" {
+			fmt.Printf("  Would create: %d\n  Would update: %d\n", counts["would create"], counts["would update"])
+		}
+	} else {
+		status, outcome := green("✓"), "finished"
+		if failed > 0 {
+			status = red("✗")
+		}
+		if interrupted || readErr != nil {
+			status, outcome = red("✗"), "stopped"
+		}
+		fmt.Printf("%s Import %s: %d created, %d updated, %d failed\n", status, outcome, counts["created"], counts["updated"], failed)
+	}
+	if failed > 0 {
+		fmt.Printf("  Error report: %s\n", errorsPath)
+	}
+
+	switch {
+	case readErr != nil:
+		saveCheckpoint()
+		return fmt.Errorf("stopped reading %s: %w (resume with --resume after fixing the file)", path, readErr)
+	case interrupted:
+		saveCheckpoint()
+		return fmt.Errorf("import interrupted at row %d; continue with --resume", progress.next)
+	}
+
+	if !importDryRunFlag {
+		if err := os.Remove(checkpointPath); err != nil && !os.IsNotExist(err) {
+			utils.PrintWarning(fmt.Sprintf("Failed to remove checkpoint: %v", err))
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rows failed", failed, failed+succeeded)
+	}
+	return nil
+}
+
+// importRecord creates a row, or updates the record matching the upsert key.
+// In a dry run only the upsert lookup is made.
+func importRecord(ctx *config.Context, client *pocketbase.Client, collection string, data map[string]interface{}, key interface{}, wait func()) (string, error) {
+	var existing map[string]interface{}
+	if importUpsertKeyFlag != "" {
+		if key == nil || utils.FormatCell(key) == "" {
+			return "", fmt.Errorf("upsert key '%s' is missing", importUpsertKeyFlag)
+		}
+
+		wait()
+		record, err := findRecordByKey(client, collection, importUpsertKeyFlag, key)
+		if err != nil {
+			return "", err
+		}
+		existing = record
+
+		// Updates only need the fields they change; new records must be complete
+		if existing != nil {
+			err = validateAgainstSchema(ctx, data, collection, true)
+		} else {
+			err = validateCreateData(ctx, data, collection)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if importDryRunFlag {
+		switch {
+		case importUpsertKeyFlag == "":
+			return "valid", nil
+		case existing != nil:
+			return "would update", nil
+		default:
+			return "would create", nil
+		}
+	}
+
+	wait()
+	if existing != nil {
+		id, _ := existing["id"].(string)
+		if _, err := client.UpdateRecord(collection, id, data); err != nil {
+			return "", err
+		}
+		return "updated", nil
+	}
+
+	if _, err := client.CreateRecord(collection, data); err != nil {
+		return "", err
+	}
+	return "created", nil
+}
+
+// findRecordByKey returns the record whose key field equals value, or nil if there is none
+func findRecordByKey(client *pocketbase.Client, collection, key string, value interface{}) (map[string]interface{}, error) {
+	literal := utils.FormatCell(value)
+	if _, isString := value.(string); isString {
+		literal = pocketbase.QuoteFilterString(literal)
+	}
+
+	result, err := client.ListRecords(collection, &pocketbase.ListOptions{
+		Page:      1,
+		PerPage:   2,
+		Filter:    fmt.Sprintf("%s = %s", key, literal),
+		SkipTotal: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(result.Items) {
+	case 0:
+		return nil, nil
+	case 1:
+		return result.Items[0], nil
+	default:
+		return nil, fmt.Errorf("more than one record has %s = %s; the upsert key must be unique", key, literal)
+	}
+}
+
+// importErrorMessage returns the friendly message of PocketBase errors
+func importErrorMessage(err error) string {
+	var pbErr *pocketbase.PocketBaseError
+	if errors.As(err, &pbErr) {
+		return pbErr.GetFriendlyMessage()
+	}
+	return err.Error()
+}
+
+// detectImportFormat returns the input format from --format or the file extension
+func detectImportFormat(path, format string) (string, error) {
+	switch strings.ToLower(format) {
+	case importFormatCSV, importFormatTSV, importFormatNDJSON, importFormatJSON:
+		return strings.ToLower(format), nil
+	case "jsonl":
+		return importFormatNDJSON, nil
+	case "":
+	default:
+		return "", fmt.Errorf("invalid --format '%s'. Valid formats: csv, tsv, ndjson, json", format)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return importFormatCSV, nil
+	case ".tsv":
+		return importFormatTSV, nil
+	case ".ndjson", ".jsonl":
+		return importFormatNDJSON, nil
+	case ".json":
+		return importFormatJSON, nil
+	}
+	return "", fmt.Errorf("cannot tell the format of %s from its extension; use --format csv|tsv|ndjson|json", path)
+}
+
+// parseImportMapping parses --map column=field pairs (an empty field drops the column)
+func parseImportMapping(pairs []string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, pair := range pairs {
+		from, to, ok := strings.Cut(pair, "=")
+		from = strings.TrimSpace(from)
+		if !ok || from == "" {
+			return nil, fmt.Errorf("invalid --map value '%s', expected COLUMN=FIELD", pair)
+		}
+		mapping[from] = strings.TrimSpace(to)
+	}
+	return mapping, nil
+}
+
+// mapImportFields renames or drops fields according to the mapping and
+// removes fields managed by PocketBase. The upsert key value is returned
+// separately, read before managed fields are removed so the id can be the key.
+func mapImportFields(data map[string]interface{}, mapping map[string]string) (map[string]interface{}, interface{}) {
+	mapped := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		if to, ok := mapping[key]; ok {
+			if to == "" {
+				continue
+			}
+			key = to
+		}
+		mapped[key] = value
+	}
+
+	var upsertKey interface{}
+	if importUpsertKeyFlag != "" {
+		upsertKey = mapped[importUpsertKeyFlag]
+	}
+
+	for _, field := range importManagedFields {
+		delete(mapped, field)
+	}
+	for key := range mapped {
+		if strings.HasPrefix(key, "expand.") {
+			delete(mapped, key)
+		}
+	}
+	return mapped, upsertKey
+}
+
+// newImportReader returns a reader for the given input format
+func newImportReader(r io.Reader, format string) (importReader, error) {
+	switch format {
+	case importFormatCSV, importFormatTSV:
+		reader := csv.NewReader(bufio.NewReader(r))
+		if format == importFormatTSV {
+			reader.Comma = '\t'
+		}
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read header: %w", err)
+		}
+		for i := range header {
+			header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+		}
+		return &csvImportReader{reader: reader, header: header}, nil
+
+	case importFormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		return &ndjsonImportReader{scanner: scanner}, nil
+
+	case importFormatJSON:
+		decoder := json.NewDecoder(bufio.NewReader(r))
+		if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+			return nil, fmt.Errorf("JSON input must be an array of records")
+		}
+		return &jsonArrayImportReader{decoder: decoder}, nil
+	}
+	return nil, fmt.Errorf("unsupported import format: %s", format)
+}
+
+// csvImportReader reads rows from CSV or TSV with a header line
+type csvImportReader struct {
+	reader *csv.Reader
+	header []string
+	row    int
+}
+
+// Next returns the next CSV row. Empty cells are left out so the field keeps
+// its default (or current value when upserting); JSON arrays and objects are decoded.
+func (c *csvImportReader) Next() (*importRow, error) {
+	record, err := c.reader.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	c.row++
+
+	row := &importRow{number: c.row}
+	if err != nil {
+		var parseErr *csv.ParseError
+		if !errors.As(err, &parseErr) {
+			return nil, err
+		}
+		row.err = err
+		return row, nil
+	}
+
+	row.data = make(map[string]interface{}, len(record))
+	for i, cell := range record {
+		if i >= len(c.header) || c.header[i] == "" || cell == "" {
+			continue
+		}
+		row.data[c.header[i]] = csvValue(cell)
+	}
+	return row, nil
+}
+
+// csvValue converts a cell, decoding JSON arrays and objects (multi-relations, json fields)
+func csvValue(cell string) interface{} {
+	trimmed := strings.TrimSpace(cell)
+	if strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{") {
+		var value interface{}
+		if err := json.Unmarshal([]byte(trimmed), &value); err == nil {
+			return value
+		}
+	}
+	return cell
+}
+
+// ndjsonImportReader reads one JSON object per line
+type ndjsonImportReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// Next returns the record on the next non-empty line
+func (n *ndjsonImportReader) Next() (*importRow, error) {
+	for n.scanner.Scan() {
+		n.line++
+		line := bytes.TrimSpace(n.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		row := &importRow{number: n.line}
+		if err := json.Unmarshal(line, &row.data); err != nil {
+			row.err = fmt.Errorf("invalid JSON: %w", err)
+		}
+		return row, nil
+	}
+	if err := n.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// jsonArrayImportReader reads the elements of a JSON array one at a time
+type jsonArrayImportReader struct {
+	decoder *json.Decoder
+	index   int
+}
+
+// Next returns the next array element
+func (j *jsonArrayImportReader) Next() (*importRow, error) {
+	if !j.decoder.More() {
+		return nil, io.EOF
+	}
+	j.index++
+
+	row := &importRow{number: j.index}
+	var element json.RawMessage
+	if err := j.decoder.Decode(&element); err != nil {
+		return nil, fmt.Errorf("invalid JSON at element %d: %w", j.index, err)
+	}
+	if err := json.Unmarshal(element, &row.data); err != nil || row.data == nil {
+		row.err = fmt.Errorf("element is not a JSON object")
+	}
+	return row, nil
+}
+
+// importProgress tracks the first row not yet finished while rows complete out of order
+type importProgress struct {
+	next int
+	done map[int]bool
+}
+
+// complete marks a row as finished and advances past contiguous finished rows
+func (p *importProgress) complete(row int) {
+	if row < p.next {
+		return
+	}
+	p.done[row] = true
+	for p.done[p.next] {
+		delete(p.done, p.next)
+		p.next++
+	}
+}
+
+// importReport writes failed rows to an NDJSON file, created on the first failure
+type importReport struct {
+	path       string
+	appendMode bool
+	file       *os.File
+	encoder    *json.Encoder
+}
+
+// write records a failed row
+func (r *importReport) write(row *importRow, message string) error {
+	if r.file == nil {
+		flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if r.appendMode {
+			flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		}
+		// The report holds full rows, which may include passwords
+		file, err := os.OpenFile(r.path, flags, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to write error report: %w", err)
+		}
+		if err := file.Chmod(0600); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to write error report: %w", err)
+		}
+		r.file = file
+		r.encoder = json.NewEncoder(file)
+	}
+
+	return r.encoder.Encode(importErrorEntry{Row: row.number, Error: message, Data: row.data})
+}
+
+// close closes the report file if one was written
+func (r *importReport) close() {
+	if r.file != nil {
+		r.file.Close()
+	}
+}
+
+// loadImportCheckpoint reads a checkpoint written by an earlier import
+func loadImportCheckpoint(path string) (*importCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no checkpoint found at %s", path)
+		}
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var checkpoint importCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+	if checkpoint.NextRow < 1 {
+		checkpoint.NextRow = 1
+	}
+	return &checkpoint, nil
+}
+
+// saveImportCheckpoint writes the checkpoint atomically
+func saveImportCheckpoint(path string, checkpoint importCheckpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	// Private like the error report; the checkpoint names the source file
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	if err := os.Chmod(tmp, 0600); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
@@ -21,6 +21,12 @@ var (
 	sortFlag   string
 	fieldsFlag []string
 	expandFlag []string
+
+	// Auto-pagination flags
+	allFlag         bool
+	maxFlag         int
+	skipTotalFlag   bool
+	concurrencyFlag int
 	
 	// Create/Update flags
 	fileFlag string
@@ -31,11 +37,31 @@ var (
 	
 	// Common flags
 	outputFlag string
+
+	// Table flags
+	columnsFlag []string
+	wideFlag    bool
+
+	// Import flags
+	importFormatFlag     string
+	importMapFlag        []string
+	importUpsertKeyFlag  string
+	importDryRunFlag     bool
+	importErrorsFlag     string
+	importCheckpointFlag string
+	importResumeFlag     bool
+
+	// Validation flags
+	refreshSchemaFlag bool
+
+	// Flags for actions that change many records (import, --filter)
+	workersFlag int
+	rateFlag    float64
 )
 
 // CollectionsCmd represents the collections command
 var CollectionsCmd = &cobra.Command{
-	Use:   "collections <collection> <action> [args]",
+	Use:   "collections [<collection> <action> [args]]",
 	Short: "Manage Stone-Age.io collections",
 	Long: `Perform CRUD operations on Stone-Age.io collections through PocketBase.
 
@@ -49,13 +75,35 @@ defines which collections are accessible based on your Stone-Age.io deployment.
 Usage Pattern:
   flint collections <collection> <action> [args] [flags]
 
+Run without arguments to list every collection on the server, with its type
+and whether your context makes it available.
+
 Examples:
+  # Which collections does the server have?
+  flint collections
+
+  # Fields, indexes and API rules of a collection
+  flint collections things describe
+
   # List all edges in your organization
   flint collections edges list
 
   # List with filtering and custom fields
   flint collections edges list --filter 'active=true && region="us-west"' --fields name,code,region
 
+  # Export every thing, walking all pages (streams, so memory stays flat)
+  flint collections things list --all --skip-total -o json > things.json
+
+  # First 2000 matching audit log entries, fetching 4 pages at a time
+  flint collections audit_logs list --max 2000 --sort -created --concurrency 4
+
+  # Spreadsheet-friendly export; expanded relations become dotted columns
+  flint collections edges list --all --fields id,name,expand.location.name --expand location -o csv
+
+  # Pick table columns, or show every field
+  flint collections things list -o table --columns id,name,edge_id,updated --expand edge_id
+  flint collections things list -o table --wide
+
   # Get a specific user by ID with expanded relations
   flint collections users get user_abc123def456 --expand organizations
 
@@ -68,15 +116,32 @@ Examples:
   # Update an edge device
   flint collections edges update edge_123 '{"name":"Updated Edge Name"}' --output table
 
+  # Edit a record in $EDITOR (YAML; use -o json for JSON); only changed fields are sent
+  flint collections edges edit edge_123
+
+  # Onboard a site's things from a spreadsheet, updating those that already exist
+  flint collections things import site-12.csv --upsert-key code --map "Device Name=name" --dry-run
+  flint collections things import site-12.csv --upsert-key code --map "Device Name=name"
+
   # Delete a thing with confirmation skip
   flint collections things delete thing_456 --force
 
+  # Retire every decommissioned thing (shows a sample, then asks for the count)
+  flint collections things update --filter 'status="decommissioned"' '{"active":false}'
+  flint collections things delete --filter 'status="decommissioned" && updated<"2024-01-01"'
+
 Available Actions:
   list     List records from a collection with filtering and pagination
+           (--all or --max walk the pages and stream the records)
   get      Get a single record by ID with optional expansion
   create   Create a new record from JSON data or file
   update   Update an existing record with JSON data or file
+           (--filter updates every matching record)
   delete   Delete a record with confirmation
+           (--filter deletes every matching record)
+  edit     Edit a record in $EDITOR and update the fields that changed
+  import   Create or upsert records from a CSV, TSV, NDJSON or JSON array file
+  describe Show the collection's fields, indexes and API rules
 
 Available Collections (depends on your context):
   organizations    Multi-tenant organization management
@@ -96,7 +161,12 @@ Note: All operations are automatically scoped to your current organization
 via PocketBase rules. You must be authenticated and have the appropriate
 permissions for the target collection.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Need at least collection and action
+		// Without arguments, give an overview of the server's collections
+		if len(args) == 0 {
+			return handleCollectionsOverview()
+		}
+
+		// Otherwise need at least collection and action
 		if len(args) < 2 {
 			return fmt.Errorf("missing required arguments: <collection> <action>")
 		}
@@ -117,6 +187,19 @@ permissions for the target collection.`,
 			return err
 		}
 
+		// Fill unset read flags from .flint.yaml collection defaults
+		if resolvedAction == "list" || resolvedAction == "get" {
+			applyCollectionDefaults(cmd, collection)
+		}
+
+		// When walking pages, --limit is the page size; default to the largest
+		if (allFlag || maxFlag > 0) && !cmd.Flags().Changed("limit") {
+			limitFlag = pocketbase.MaxPerPage
+			if maxFlag > 0 && maxFlag < limitFlag {
+				limitFlag = maxFlag
+			}
+		}
+
 		// Route to appropriate action handler
 		return routeToAction(ctx, collection, resolvedAction, actionArgs)
 	},
@@ -132,10 +215,14 @@ func init() {
 	// List-specific flags
 	CollectionsCmd.Flags().IntVar(&offsetFlag, "offset", 0, "Number of records to skip (for pagination)")
 	CollectionsCmd.Flags().IntVar(&limitFlag, "limit", 30, "Maximum number of records to return")
-	CollectionsCmd.Flags().StringVar(&filterFlag, "filter", "", "PocketBase filter expression (e.g., 'active=true && name~\"test\"')")
+	CollectionsCmd.Flags().StringVar(&filterFlag, "filter", "", "PocketBase filter expression (e.g., 'active=true && name~\"test\"'); with update/delete, changes every match")
 	CollectionsCmd.Flags().StringVar(&sortFlag, "sort", "", "Sort expression (e.g., 'name', '-created', 'name,-updated')")
 	CollectionsCmd.Flags().StringSliceVar(&fieldsFlag, "fields", nil, "Specific fields to return (comma-separated)")
 	CollectionsCmd.Flags().StringSliceVar(&expandFlag, "expand", nil, "Relations to expand (comma-separated)")
+	CollectionsCmd.Flags().BoolVar(&allFlag, "all", false, "Fetch every page and stream all matching records (--limit sets the page size)")
+	CollectionsCmd.Flags().IntVar(&maxFlag, "max", 0, "Fetch pages until this many records have been streamed")
+	CollectionsCmd.Flags().BoolVar(&skipTotalFlag, "skip-total", false, "Skip the total count query (faster on large collections)")
+	CollectionsCmd.Flags().IntVar(&concurrencyFlag, "concurrency", 1, "Pages to fetch in parallel with --all/--max (1-10)")
 	
 	// Create/Update flags
 	CollectionsCmd.Flags().StringVar(&fileFlag, "file", "", "Path to JSON file containing record data")
@@ -145,7 +232,27 @@ func init() {
 	CollectionsCmd.Flags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress success messages")
 	
 	// Common flags
-	CollectionsCmd.Flags().StringVarP(&outputFlag, "output", "o", "", "Output format (json|yaml|table)")
+	CollectionsCmd.Flags().StringVarP(&outputFlag, "output", "o", "", "Output format (json|yaml|table|ndjson|csv|tsv)")
+
+	// Table flags
+	CollectionsCmd.Flags().StringSliceVar(&columnsFlag, "columns", nil, "Table columns to show, in order (comma-separated, e.g. id,name,expand.location.name)")
+	CollectionsCmd.Flags().BoolVar(&wideFlag, "wide", false, "Show every field in tables, without fitting to the terminal width")
+
+	// Import flags
+	CollectionsCmd.Flags().StringVar(&importFormatFlag, "format", "", "Import file format (csv|tsv|ndjson|json, default from the file extension)")
+	CollectionsCmd.Flags().StringArrayVar(&importMapFlag, "map", nil, "Map an import column to a field, COLUMN=FIELD (empty FIELD drops the column, repeatable)")
+	CollectionsCmd.Flags().StringVar(&importUpsertKeyFlag, "upsert-key", "", "Update the record whose field matches instead of creating a new one (e.g. code)")
+	CollectionsCmd.Flags().BoolVar(&importDryRunFlag, "dry-run", false, "Validate the import without writing records")
+	CollectionsCmd.Flags().StringVar(&importErrorsFlag, "errors", "", "Error report file (default <file>.errors.ndjson)")
+	CollectionsCmd.Flags().StringVar(&importCheckpointFlag, "checkpoint", "", "Checkpoint file for resuming (default <file>.checkpoint)")
+	CollectionsCmd.Flags().BoolVar(&importResumeFlag, "resume", false, "Continue an interrupted import from its checkpoint")
+
+	// Validation flags
+	CollectionsCmd.Flags().BoolVar(&refreshSchemaFlag, "refresh-schema", false, "Fetch the collection schema again instead of using the cached copy")
+
+	// Bulk flags (import, update/delete with --filter)
+	CollectionsCmd.Flags().IntVar(&workersFlag, "workers", 4, "Records imported, updated or deleted in parallel (1-16)")
+	CollectionsCmd.Flags().Float64Var(&rateFlag, "rate", 10, "Maximum requests per second for import and --filter changes (0 = unlimited)")
 }
 
 // SetConfigManager sets the configuration manager for the collections commands
@@ -182,16 +289,19 @@ func validateActiveContext() (*config.Context, error) {
 
 	ctx, err := configManager.GetActiveContext()
 	if err != nil {
+		if config.IsContextNotFound(err) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("no active context set. Use 'flint context select <name>' to set one")
 	}
 
-	// Check authentication
-	if ctx.PocketBase.AuthToken == "" {
+	// Check authentication (FLINT_PB_TOKEN stands in for a stored session)
+	if !pocketbase.HasSession(ctx) {
 		return nil, fmt.Errorf("authentication required. Run 'flint auth pb' to authenticate")
 	}
 
-	// Check if authentication is still valid
-	if !pocketbase.IsAuthValid(ctx) {
+	// Check if authentication is still valid (tokens close to expiry are refreshed by the client)
+	if !pocketbase.CanRefreshAuth(ctx) {
 		return nil, fmt.Errorf("authentication has expired. Run 'flint auth pb' to re-authenticate")
 	}
 
@@ -226,9 +336,38 @@ func resolveAction(partialAction string) (string, error) {
 	return cmdResolver.ResolveCommand("collections", partialAction)
 }
 
-// createPocketBaseClient creates an authenticated PocketBase client from context
+// createPocketBaseClient creates an authenticated PocketBase client from context.
+// The client refreshes the session token automatically and saves it to the context.
 func createPocketBaseClient(ctx *config.Context) *pocketbase.Client {
-	return pocketbase.NewClientFromContext(ctx)
+	// Resolve file paths relative to the context directory
+	resolved := *ctx
+	resolved.PocketBase = configManager.ResolvePocketBaseConfig(ctx)
+
+	client := pocketbase.NewClientFromContext(&resolved)
+	client.EnableAutoRefresh(configManager, ctx)
+	return client
+}
+
+// applyCollectionDefaults applies project collection defaults to flags not given on the command line
+func applyCollectionDefaults(cmd *cobra.Command, collection string) {
+	project, _ := configManager.GetProjectConfig()
+	defaults := project.DefaultsFor(collection)
+
+	if defaults.Limit > 0 && !cmd.Flags().Changed("limit") {
+		limitFlag = defaults.Limit
+	}
+	if defaults.Sort != "" && !cmd.Flags().Changed("sort") {
+		sortFlag = defaults.Sort
+	}
+	if defaults.Filter != "" && !cmd.Flags().Changed("filter") {
+		filterFlag = defaults.Filter
+	}
+	if len(defaults.Fields) > 0 && !cmd.Flags().Changed("fields") {
+		fieldsFlag = defaults.Fields
+	}
+	if len(defaults.Expand) > 0 && !cmd.Flags().Changed("expand") {
+		expandFlag = defaults.Expand
+	}
 }
 
 // routeToAction routes the command to the appropriate action handler
@@ -244,8 +383,14 @@ func routeToAction(ctx *config.Context, collection, action string, args []string
 		return handleUpdateAction(ctx, collection, args)
 	case "delete":
 		return handleDeleteAction(ctx, collection, args)
+	case "import":
+		return handleImportAction(ctx, collection, args)
+	case "describe":
+		return handleDescribeAction(ctx, collection, args)
+	case "edit":
+		return handleEditAction(ctx, collection, args)
 	default:
-		return fmt.Errorf("unknown action '%s'. Available actions: list, get, create, update, delete", action)
+		return fmt.Errorf("unknown action '%s'. Available actions: list, get, create, update, delete, import, describe, edit", action)
 	}
 }
 
@@ -1,13 +1,32 @@
 package collections
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 
+	"flint-cli/internal/config"
+	"flint-cli/internal/pocketbase"
 	"flint-cli/internal/utils"
 )
 
+// Collection schemas used for validation, loaded once per command
+var (
+	schemaOnce        sync.Once
+	schemaCollections []pocketbase.Collection
+)
+
+// Collections whose organization_id is set from the context
+var organizationScopedCollections = map[string]bool{
+	"users":     true,
+	"edges":     true,
+	"things":    true,
+	"locations": true,
+}
+
 // validateCreateData validates the JSON data for creating a record
-func validateCreateData(data map[string]interface{}, collection string) error {
+func validateCreateData(ctx *config.Context, data map[string]interface{}, collection string) error {
 	if data == nil || len(data) == 0 {
 		return fmt.Errorf("record data cannot be empty")
 	}
@@ -21,25 +40,21 @@ func validateCreateData(data map[string]interface{}, collection string) error {
 		}
 	}
 
-	// Collection-specific validation
-	switch collection {
-	case "users":
-		return validateUserCreateData(data)
-	case "organizations":
-		return validateOrganizationCreateData(data)
-	case "edges":
-		return validateEdgeCreateData(data)
-	case "things":
-		return validateThingCreateData(data)
-	case "locations":
-		return validateLocationCreateData(data)
+	// Check types, required fields and options against the live schema
+	if err := validateAgainstSchema(ctx, data, collection, false); err != nil {
+		return err
+	}
+
+	// organization_id is set automatically based on context
+	if _, exists := data["organization_id"]; exists && organizationScopedCollections[collection] {
+		utils.PrintWarning("organization_id is automatically set based on your current context")
 	}
 
 	return nil
 }
 
 // validateUpdateData validates the JSON data for updating a record
-func validateUpdateData(data map[string]interface{}, collection string) error {
+func validateUpdateData(ctx *config.Context, data map[string]interface{}, collection string) error {
 	if data == nil || len(data) == 0 {
 		return fmt.Errorf("update data cannot be empty")
 	}
@@ -53,12 +68,17 @@ func validateUpdateData(data map[string]interface{}, collection string) error {
 		}
 	}
 
+	// Only the fields being changed are checked against the schema
+	if err := validateAgainstSchema(ctx, data, collection, true); err != nil {
+		return err
+	}
+
 	// Warn about organization_id changes
 	if _, exists := data["organization_id"]; exists {
 		utils.PrintWarning("Updating organization_id may cause access issues. This field is typically managed by your context.")
 	}
 
-	// Collection-specific validation
+	// Collection-specific warnings
 	switch collection {
 	case "users":
 		return validateUserUpdateData(data)
@@ -75,93 +95,85 @@ func validateUpdateData(data map[string]interface{}, collection string) error {
 	return nil
 }
 
-// validateUserCreateData validates user creation data
-func validateUserCreateData(data map[string]interface{}) error {
-	requiredFields := []string{"email", "password"}
-	
-	for _, field := range requiredFields {
-		if _, exists := data[field]; !exists {
-			return fmt.Errorf("field '%s' is required for user creation", field)
-		}
-	}
-
-	// Validate email format if present
-	if email, ok := data["email"].(string); ok {
-		if err := utils.ValidateEmail(email); err != nil {
-			return fmt.Errorf("invalid email: %w", err)
+// validateAgainstSchema checks record data against the collection's schema
+// from PocketBase, reporting every problem at once. Without access to the
+// schema (non-superuser sessions) only the built-in required fields of new
+// records are checked and PocketBase validates the rest.
+func validateAgainstSchema(ctx *config.Context, data map[string]interface{}, collection string, partial bool) error {
+	collections := loadSchemas(ctx)
+	schema := pocketbase.FindCollection(collections, collection)
+	if schema == nil {
+		if partial {
+			return nil
 		}
+		return validateRequiredFields(data, collection)
 	}
-
-	// Warn about organization_id - it's usually set automatically
-	if _, exists := data["organization_id"]; exists {
-		utils.PrintWarning("organization_id is typically set automatically based on your context")
-	}
-
-	return nil
+	return schema.ValidateRecord(data, partial, collections)
 }
 
-// validateOrganizationCreateData validates organization creation data
-func validateOrganizationCreateData(data map[string]interface{}) error {
-	requiredFields := []string{"name", "code", "account_name"}
-	
-	for _, field := range requiredFields {
-		if _, exists := data[field]; !exists {
-			return fmt.Errorf("field '%s' is required for organization creation", field)
+// loadSchemas returns the collection schemas of the context, cached per
+// context, or nil when they cannot be read
+func loadSchemas(ctx *config.Context) []pocketbase.Collection {
+	schemaOnce.Do(func() {
+		if refreshSchemaFlag {
+			if err := pocketbase.InvalidateSchemaCache(ctx.Name); err != nil {
+				utils.PrintWarning(err.Error())
+			}
 		}
-	}
 
-	return nil
-}
-
-// validateEdgeCreateData validates edge creation data
-func validateEdgeCreateData(data map[string]interface{}) error {
-	requiredFields := []string{"name", "code", "type", "region"}
-	
-	for _, field := range requiredFields {
-		if _, exists := data[field]; !exists {
-			return fmt.Errorf("field '%s' is required for edge creation", field)
+		collections, err := createPocketBaseClient(ctx).CachedCollections(ctx.Name)
+		if errors.Is(err, pocketbase.ErrSchemaForbidden) {
+			// Expected for non-superusers; the built-in checks apply instead
+			utils.PrintDebug(fmt.Sprintf("Skipping schema validation: %v", err))
+			return
 		}
-	}
-
-	// organization_id is set automatically based on context
-	if _, exists := data["organization_id"]; exists {
-		utils.PrintWarning("organization_id is automatically set based on your current context")
-	}
-
-	return nil
+		if err != nil {
+			utils.PrintWarning(fmt.Sprintf("Could not read the collection schemas (%v); only basic checks are applied", err))
+			return
+		}
+		schemaCollections = collections
+	})
+	return schemaCollections
 }
 
-// validateThingCreateData validates thing creation data
-func validateThingCreateData(data map[string]interface{}) error {
-	requiredFields := []string{"name", "code", "type", "edge_id"}
-	
-	for _, field := range requiredFields {
-		if _, exists := data[field]; !exists {
-			return fmt.Errorf("field '%s' is required for thing creation", field)
-		}
+// schemaFieldOrder returns the schema order of the collection's fields, when the schema can be read
+func schemaFieldOrder(ctx *config.Context, collection string) []string {
+	schema := pocketbase.FindCollection(loadSchemas(ctx), collection)
+	if schema == nil {
+		return nil
 	}
 
-	// organization_id is set automatically based on context
-	if _, exists := data["organization_id"]; exists {
-		utils.PrintWarning("organization_id is automatically set based on your current context")
+	var order []string
+	for _, field := range schema.SchemaFields() {
+		order = append(order, field.Name)
 	}
+	return order
+}
 
-	return nil
+// Fields required on create when the collection schema cannot be read
+var requiredCreateFields = map[string][]string{
+	"users":         {"email", "password"},
+	"organizations": {"name", "code", "account_name"},
+	"edges":         {"name", "code", "type", "region"},
+	"things":        {"name", "code", "type", "edge_id"},
+	"locations":     {"name", "type", "code"},
 }
 
-// validateLocationCreateData validates location creation data
-func validateLocationCreateData(data map[string]interface{}) error {
-	requiredFields := []string{"name", "type", "code"}
-	
-	for _, field := range requiredFields {
+// validateRequiredFields checks the built-in required fields of the
+// Stone-Age.io collections, and the email format of new users
+func validateRequiredFields(data map[string]interface{}, collection string) error {
+	for _, field := range requiredCreateFields[collection] {
 		if _, exists := data[field]; !exists {
-			return fmt.Errorf("field '%s' is required for location creation", field)
+			return fmt.Errorf("field '%s' is required for %s creation", field, strings.TrimSuffix(collection, "s"))
 		}
 	}
 
-	// organization_id is set automatically based on context
-	if _, exists := data["organization_id"]; exists {
-		utils.PrintWarning("organization_id is automatically set based on your current context")
+	if collection == "users" {
+		if email, ok := data["email"].(string); ok {
+			if err := utils.ValidateEmail(email); err != nil {
+				return fmt.Errorf("invalid email: %w", err)
+			}
+		}
 	}
 
 	return nil
@@ -169,13 +181,6 @@ func validateLocationCreateData(data map[string]interface{}) error {
 
 // validateUserUpdateData validates user update data
 func validateUserUpdateData(data map[string]interface{}) error {
-	// Validate email format if present
-	if email, ok := data["email"].(string); ok {
-		if err := utils.ValidateEmail(email); err != nil {
-			return fmt.Errorf("invalid email: %w", err)
-		}
-	}
-
 	// Warn about sensitive field updates
 	sensitiveFields := []string{"password", "current_organization_id"}
 	for _, field := range sensitiveFields {
@@ -0,0 +1,182 @@
+package context
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"flint-cli/internal/config"
+	"flint-cli/internal/utils"
+)
+
+var (
+	clonePBURL          string
+	clonePBCollection   string
+	cloneOrganizationID string
+	cloneNATSServers    []string
+	cloneNATSAuthMethod string
+	cloneKeepAuth       bool
+)
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone <src> <dst>",
+	Short: "Create a new context from an existing one",
+	Long: `Create a new context as a copy of an existing context.
+
+All settings and context files (such as nats.creds) are copied. Individual
+settings can be overridden with flags. The PocketBase session is not copied
+unless --keep-auth is given, so the new context starts unauthenticated.
+
+The new context directory is assembled in a temporary location and moved into
+place in a single step, so an interrupted clone never leaves a partial context.
+
+Examples:
+  flint context clone production staging --pb-url https://staging.stone-age.io
+  flint context clone production prod-acme --organization org_acme123 --keep-auth
+  flint context clone dev dev-local --nats-servers nats://localhost:4222`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateConfigManager(); err != nil {
+			return err
+		}
+
+		src, dst := args[0], args[1]
+
+		if !configManager.ContextExists(src) {
+			return fmt.Errorf("context '%s' not found", src)
+		}
+		if err := utils.ValidateContextName(dst); err != nil {
+			return err
+		}
+		if configManager.ContextExists(dst) {
+			return fmt.Errorf("context '%s' already exists", dst)
+		}
+
+		// Validate overrides before touching the filesystem
+		if cmd.Flags().Changed("pb-url") {
+			if err := utils.ValidateURL(clonePBURL); err != nil {
+				return fmt.Errorf("invalid --pb-url: %w", err)
+			}
+		}
+		if cmd.Flags().Changed("pb-auth-collection") {
+			if err := utils.ValidateAuthCollection(clonePBCollection); err != nil {
+				return err
+			}
+		}
+		if cmd.Flags().Changed("nats-servers") {
+			if err := utils.ValidateNATSServers(cloneNATSServers); err != nil {
+				return err
+			}
+		}
+		if cmd.Flags().Changed("nats-auth-method") {
+			if err := utils.ValidateNATSAuthMethod(cloneNATSAuthMethod); err != nil {
+				return err
+			}
+		}
+
+		ctx, err := configManager.CloneContext(src, dst, func(ctx *config.Context) {
+			if cmd.Flags().Changed("pb-url") {
+				ctx.PocketBase.URL = clonePBURL
+			}
+			if cmd.Flags().Changed("pb-auth-collection") {
+				ctx.PocketBase.AuthCollection = clonePBCollection
+			}
+			if cmd.Flags().Changed("organization") {
+				ctx.PocketBase.OrganizationID = cloneOrganizationID
+			}
+			if cmd.Flags().Changed("nats-servers") {
+				ctx.NATS.Servers = cloneNATSServers
+			}
+			if cmd.Flags().Changed("nats-auth-method") {
+				ctx.NATS.AuthMethod = cloneNATSAuthMethod
+			}
+			if !cloneKeepAuth {
+				ctx.PocketBase.AuthToken = ""
+				ctx.PocketBase.AuthExpires = nil
+				ctx.PocketBase.AuthRecord = nil
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("failed to clone context: %w", err)
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		cyan := color.New(color.FgCyan).SprintFunc()
+
+		fmt.Printf("%s Context '%s' cloned to '%s'\n", green("✓"), src, cyan(dst))
+		fmt.Printf("\nContext directory: %s\n", configManager.GetContextDir(dst))
+		fmt.Printf("\nContext Configuration:\n")
+		fmt.Printf("  PocketBase URL: %s\n", ctx.PocketBase.URL)
+		fmt.Printf("  Auth Collection: %s\n", ctx.PocketBase.AuthCollection)
+		if ctx.PocketBase.OrganizationID != "" {
+			fmt.Printf("  Organization ID: %s\n", ctx.PocketBase.OrganizationID)
+		}
+		fmt.Printf("  NATS Servers: %s\n", strings.Join(ctx.NATS.Servers, ", "))
+		fmt.Printf("  NATS Auth Method: %s\n", ctx.NATS.AuthMethod)
+
+		fmt.Printf("\nNext steps:\n")
+		fmt.Printf("  Select this context: %s\n", cyan(fmt.Sprintf("flint context select %s", dst)))
+		if ctx.PocketBase.AuthToken == "" {
+			fmt.Printf("  Authenticate with PocketBase: %s\n", cyan("flint auth pb"))
+		}
+
+		return nil
+	},
+}
+
+var renameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a context",
+	Long: `Rename a context, moving its directory and all context files.
+
+The context directory is moved in a single step. If the renamed context is
+the active context, active_context in config.yaml is updated as well.
+
+Examples:
+  flint context rename dev development
+  flint context mv staging staging-eu`,
+	Aliases: []string{"mv"},
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateConfigManager(); err != nil {
+			return err
+		}
+
+		oldName, newName := args[0], args[1]
+		if err := utils.ValidateContextName(newName); err != nil {
+			return err
+		}
+
+		if err := configManager.RenameContext(oldName, newName); err != nil {
+			return fmt.Errorf("failed to rename context: %w", err)
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		cyan := color.New(color.FgCyan).SprintFunc()
+
+		fmt.Printf("%s Context '%s' renamed to '%s'\n", green("✓"), oldName, cyan(newName))
+		fmt.Printf("  Directory: %s\n", configManager.GetContextDir(newName))
+
+		// Warn about references that can't be updated automatically
+		if project, _ := configManager.GetProjectConfig(); project != nil && project.Context == oldName {
+			utils.PrintWarning(fmt.Sprintf("%s still refers to '%s'. Update it to '%s'.",
+				configManager.ProjectSource(), oldName, newName))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	cloneCmd.Flags().StringVar(&clonePBURL, "pb-url", "", "Override the PocketBase server URL")
+	cloneCmd.Flags().StringVar(&clonePBCollection, "pb-auth-collection", "",
+		"Override the PocketBase auth collection (users|clients|edges|things|service_users|_superusers)")
+	cloneCmd.Flags().StringVar(&cloneOrganizationID, "organization", "", "Override the organization ID")
+	cloneCmd.Flags().StringSliceVar(&cloneNATSServers, "nats-servers", nil,
+		"Override the NATS server URLs (comma-separated)")
+	cloneCmd.Flags().StringVar(&cloneNATSAuthMethod, "nats-auth-method", "",
+		"Override the NATS authentication method (user_pass|token|creds|nkey)")
+	cloneCmd.Flags().BoolVar(&cloneKeepAuth, "keep-auth", false,
+		"Copy the PocketBase session to the new context")
+}
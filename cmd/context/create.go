@@ -2,11 +2,15 @@ package context
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"flint-cli/internal/config"
+	natsClient "flint-cli/internal/nats"
+	"flint-cli/internal/pocketbase"
+	"flint-cli/internal/utils"
 )
 
 var (
@@ -15,6 +19,20 @@ var (
 	organizationID   string
 	natsServers      []string
 	natsAuthMethod   string
+
+	natsTLSCAFile     string
+	natsTLSCertFile   string
+	natsTLSKeyFile    string
+	natsTLSServerName string
+	natsTLSMinVersion string
+
+	pbTLSCAFile   string
+	pbTLSCertFile string
+	pbTLSKeyFile  string
+	pbTLSInsecure bool
+	pbProxyURL    string
+	pbTimeout     string
+	pbHeaders     []string
 )
 
 var createCmd = &cobra.Command{
@@ -29,6 +47,15 @@ NATS server information, and organization details.
 The context will be created as a directory containing:
 - context.yaml: Main context configuration
 - nats.creds: NATS credentials file (when using creds auth method)
+- nats.nk: NATS NKey user seed (when using nkey auth method)
+
+For a PocketBase server behind a private CA, an HTTP proxy or an API gateway,
+the --pb-tls-*, --pb-proxy, --pb-timeout and --pb-header flags configure the
+HTTP client. Without --pb-proxy the HTTPS_PROXY/HTTP_PROXY variables apply.
+
+For NATS clusters with a private CA or mutual TLS, pass the CA bundle, client
+certificate and key with the --nats-tls-* flags. They are stored as absolute
+paths; use 'flint auth nats --tls-*' to change them later.
 
 Examples:
   flint context create production \\
@@ -40,7 +67,22 @@ Examples:
   flint context create development \\
     --pb-url http://localhost:8090 \\
     --nats-servers nats://localhost:4222 \\
-    --nats-auth-method user_pass`,
+    --nats-auth-method user_pass
+
+  flint context create onprem \\
+    --pb-url https://api.plant.example \\
+    --nats-servers tls://nats.plant.example:4222 \\
+    --nats-tls-ca /etc/flint/plant-ca.pem \\
+    --nats-tls-cert /etc/flint/client.pem \\
+    --nats-tls-key /etc/flint/client-key.pem
+
+  flint context create staging \\
+    --pb-url https://pb.staging.internal \\
+    --pb-tls-ca /etc/flint/staging-ca.pem \\
+    --pb-proxy http://proxy.corp.example:3128 \\
+    --pb-timeout 60s \\
+    --pb-header "X-Gateway-Key=abc123" \\
+    --nats-servers nats://nats.staging.internal:4222`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := validateConfigManager(); err != nil {
@@ -62,37 +104,13 @@ Examples:
 		}
 
 		// Validate auth method
-		validAuthMethods := []string{config.NATSAuthUserPass, config.NATSAuthToken, config.NATSAuthCreds}
-		validMethod := false
-		for _, method := range validAuthMethods {
-			if natsAuthMethod == method {
-				validMethod = true
-				break
-			}
-		}
-		if !validMethod {
-			return fmt.Errorf("invalid NATS auth method '%s'. Valid options: %s", 
-				natsAuthMethod, strings.Join(validAuthMethods, ", "))
+		if err := utils.ValidateNATSAuthMethod(natsAuthMethod); err != nil {
+			return err
 		}
 
 		// Validate auth collection
-		validCollections := []string{
-			config.AuthCollectionUsers,
-			config.AuthCollectionClients,
-			config.AuthCollectionEdges,
-			config.AuthCollectionThings,
-			config.AuthCollectionServiceUsers,
-		}
-		validCollection := false
-		for _, collection := range validCollections {
-			if pbAuthCollection == collection {
-				validCollection = true
-				break
-			}
-		}
-		if !validCollection {
-			return fmt.Errorf("invalid auth collection '%s'. Valid options: %s", 
-				pbAuthCollection, strings.Join(validCollections, ", "))
+		if err := utils.ValidateAuthCollection(pbAuthCollection); err != nil {
+			return err
 		}
 
 		// Check if context already exists
@@ -101,10 +119,32 @@ Examples:
 		}
 
 		// Determine NATS credentials file path based on auth method
-		var credsFilePath string
-		if natsAuthMethod == config.NATSAuthCreds {
+		var credsFilePath, nkeyFilePath string
+		switch natsAuthMethod {
+		case config.NATSAuthCreds:
 			// Use relative path for creds file in the new structure
 			credsFilePath = "./nats.creds"
+		case config.NATSAuthNKey:
+			nkeyFilePath = "./nats.nk"
+		}
+
+		headers, err := parseHeaderFlags(pbHeaders)
+		if err != nil {
+			return err
+		}
+
+		// TLS files are stored as absolute paths, as the context directory does not exist yet
+		tlsFiles := []*string{&natsTLSCAFile, &natsTLSCertFile, &natsTLSKeyFile,
+			&pbTLSCAFile, &pbTLSCertFile, &pbTLSKeyFile}
+		for _, path := range tlsFiles {
+			if *path == "" {
+				continue
+			}
+			absPath, err := filepath.Abs(*path)
+			if err != nil {
+				return fmt.Errorf("invalid path '%s': %w", *path, err)
+			}
+			*path = absPath
 		}
 
 		// Create new context configuration
@@ -115,16 +155,41 @@ Examples:
 				AuthCollection:       pbAuthCollection,
 				OrganizationID:       organizationID,
 				AvailableCollections: config.GetDefaultCollections(),
+
+				TLSCAFile:             pbTLSCAFile,
+				TLSCertFile:           pbTLSCertFile,
+				TLSKeyFile:            pbTLSKeyFile,
+				TLSInsecureSkipVerify: pbTLSInsecure,
+				ProxyURL:              pbProxyURL,
+				Timeout:               pbTimeout,
+				Headers:               headers,
 			},
 			NATS: config.NATSConfig{
 				Servers:    natsServers,
 				AuthMethod: natsAuthMethod,
 				CredsFile:  credsFilePath, // Relative path to context directory
+				NKeyFile:   nkeyFilePath,  // Relative path to context directory
 				TLSEnabled: true,          // Default to secure
 				TLSVerify:  true,          // Default to verified
+
+				TLSCAFile:     natsTLSCAFile,
+				TLSCertFile:   natsTLSCertFile,
+				TLSKeyFile:    natsTLSKeyFile,
+				TLSServerName: natsTLSServerName,
+				TLSMinVersion: natsTLSMinVersion,
 			},
 		}
 
+		if _, err := pocketbase.NewClientWithConfig(&newContext.PocketBase); err != nil {
+			return err
+		}
+		if err := natsClient.CheckTLSFiles(&newContext.NATS); err != nil {
+			return err
+		}
+		if pbTLSInsecure {
+			utils.PrintWarning("PocketBase certificate verification is disabled for this context")
+		}
+
 		// Save the context (this will create the directory structure)
 		if err := configManager.SaveContext(newContext); err != nil {
 			return fmt.Errorf("failed to save context: %w", err)
@@ -147,8 +212,20 @@ Examples:
 		if organizationID != "" {
 			fmt.Printf("  Organization ID: %s\n", organizationID)
 		}
+		if pbProxyURL != "" {
+			fmt.Printf("  PocketBase Proxy: %s\n", pocketbase.RedactURL(pbProxyURL))
+		}
+		if pbTLSCAFile != "" {
+			fmt.Printf("  PocketBase CA Bundle: %s\n", pbTLSCAFile)
+		}
 		fmt.Printf("  NATS Servers: %s\n", strings.Join(natsServers, ", "))
 		fmt.Printf("  NATS Auth Method: %s\n", natsAuthMethod)
+		if natsTLSCAFile != "" {
+			fmt.Printf("  NATS TLS CA Bundle: %s\n", natsTLSCAFile)
+		}
+		if natsTLSCertFile != "" {
+			fmt.Printf("  NATS TLS Client Certificate: %s\n", natsTLSCertFile)
+		}
 
 		// Show auth method specific information
 		switch natsAuthMethod {
@@ -157,6 +234,11 @@ Examples:
 			fmt.Printf("  NATS Creds File: %s\n", credsPath)
 			fmt.Printf("\n%s Place your NATS credentials file at: %s\n", 
 				color.New(color.FgYellow).Sprint("Note:"), credsPath)
+		case config.NATSAuthNKey:
+			nkeyPath := configManager.GetContextNKeyPath(contextName)
+			fmt.Printf("  NATS NKey Seed File: %s\n", nkeyPath)
+			fmt.Printf("\n%s Place your NATS NKey user seed at: %s\n",
+				color.New(color.FgYellow).Sprint("Note:"), nkeyPath)
 		case config.NATSAuthUserPass:
 			fmt.Printf("\n%s Configure NATS username/password using: %s\n",
 				color.New(color.FgYellow).Sprint("Note:"),
@@ -177,7 +259,7 @@ Examples:
 			fmt.Printf("  3. Set organization: %s\n", 
 				color.New(color.FgCyan).Sprint("flint context organization <org_id>"))
 		}
-		if natsAuthMethod != config.NATSAuthCreds {
+		if natsAuthMethod != config.NATSAuthCreds && natsAuthMethod != config.NATSAuthNKey {
 			fmt.Printf("  4. Configure NATS authentication: %s\n",
 				color.New(color.FgCyan).Sprint("flint auth nats"))
 		}
@@ -189,15 +271,59 @@ Examples:
 func init() {
 	createCmd.Flags().StringVar(&pbURL, "pb-url", "", "PocketBase server URL (required)")
 	createCmd.Flags().StringVar(&pbAuthCollection, "pb-auth-collection", config.AuthCollectionUsers, 
-		"PocketBase auth collection (users|clients|edges|things|service_users)")
+		"PocketBase auth collection (users|clients|edges|things|service_users|_superusers)")
 	createCmd.Flags().StringVar(&organizationID, "organization-id", "", 
 		"Organization ID (can be set later)")
 	createCmd.Flags().StringSliceVar(&natsServers, "nats-servers", nil, 
 		"NATS server URLs (comma-separated, required)")
 	createCmd.Flags().StringVar(&natsAuthMethod, "nats-auth-method", config.NATSAuthCreds, 
-		"NATS authentication method (user_pass|token|creds)")
+		"NATS authentication method (user_pass|token|creds|nkey)")
+
+	createCmd.Flags().StringVar(&pbTLSCAFile, "pb-tls-ca", "",
+		"PEM CA bundle trusted for the PocketBase server (in addition to system roots)")
+	createCmd.Flags().StringVar(&pbTLSCertFile, "pb-tls-cert", "",
+		"Client certificate for PocketBase mutual TLS")
+	createCmd.Flags().StringVar(&pbTLSKeyFile, "pb-tls-key", "",
+		"Client private key for PocketBase mutual TLS")
+	createCmd.Flags().BoolVar(&pbTLSInsecure, "pb-tls-insecure", false,
+		"Skip PocketBase certificate verification (testing only)")
+	createCmd.Flags().StringVar(&pbProxyURL, "pb-proxy", "",
+		"HTTP proxy URL for PocketBase requests")
+	createCmd.Flags().StringVar(&pbTimeout, "pb-timeout", "",
+		"PocketBase request timeout, e.g. 45s (default 30s)")
+	createCmd.Flags().StringArrayVar(&pbHeaders, "pb-header", nil,
+		"Header sent with every PocketBase request, KEY=VALUE (repeatable)")
+	createCmd.Flags().StringVar(&natsTLSCAFile, "nats-tls-ca", "",
+		"PEM CA bundle used to verify the NATS servers")
+	createCmd.Flags().StringVar(&natsTLSCertFile, "nats-tls-cert", "",
+		"Client certificate for NATS mutual TLS")
+	createCmd.Flags().StringVar(&natsTLSKeyFile, "nats-tls-key", "",
+		"Client private key for NATS mutual TLS")
+	createCmd.Flags().StringVar(&natsTLSServerName, "nats-tls-server-name", "",
+		"Server name to verify in the NATS server certificate")
+	createCmd.Flags().StringVar(&natsTLSMinVersion, "nats-tls-min-version", "",
+		"Minimum TLS version for NATS (1.0|1.1|1.2|1.3)")
 
 	// Mark required flags
 	createCmd.MarkFlagRequired("pb-url")
 	createCmd.MarkFlagRequired("nats-servers")
 }
+
+// parseHeaderFlags parses KEY=VALUE header flags
+func parseHeaderFlags(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	headers := make(map[string]string, len(values))
+	for _, pair := range values {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid header '%s', expected KEY=VALUE", pair)
+		}
+		headers[key] = value
+	}
+
+	return headers, nil
+}
@@ -40,6 +40,10 @@ Examples:
 		// Verify the context exists
 		ctx, err := configManager.LoadContext(contextName)
 		if err != nil {
+			// Context exists but could not be loaded (e.g. vault locked)
+			if configManager.ContextExists(contextName) {
+				return err
+			}
 			// Try to provide helpful suggestions
 			contexts, listErr := configManager.ListContexts()
 			if listErr == nil && len(contexts) > 0 {
@@ -0,0 +1,432 @@
+package context
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"flint-cli/internal/config"
+	natsClient "flint-cli/internal/nats"
+	"flint-cli/internal/pocketbase"
+	"flint-cli/internal/utils"
+)
+
+// Doctor check status constants
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+	checkSkip = "skip"
+)
+
+// Thresholds used by the doctor checks
+const (
+	doctorExpiryWarning = 24 * time.Hour
+	doctorSkewWarning   = 30 * time.Second
+	doctorSkewFailure   = 5 * time.Minute
+	doctorRTTWarning    = 500 * time.Millisecond
+)
+
+var doctorOutputFormat string
+
+// DoctorCheck is the result of a single diagnostic check
+type DoctorCheck struct {
+	Name    string `json:"name" yaml:"name"`
+	Status  string `json:"status" yaml:"status"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// DoctorReport is the full diagnostic report for a context
+type DoctorReport struct {
+	Context   string         `json:"context" yaml:"context"`
+	CheckedAt time.Time      `json:"checked_at" yaml:"checked_at"`
+	Healthy   bool           `json:"healthy" yaml:"healthy"`
+	Summary   map[string]int `json:"summary" yaml:"summary"`
+	Checks    []DoctorCheck  `json:"checks" yaml:"checks"`
+}
+
+// add records a check result
+func (r *DoctorReport) add(name, status, format string, args ...interface{}) {
+	r.Checks = append(r.Checks, DoctorCheck{
+		Name:    name,
+		Status:  status,
+		Message: fmt.Sprintf(format, args...),
+	})
+	r.Summary[status]++
+	if status == checkFail {
+		r.Healthy = false
+	}
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor [name]",
+	Short: "Diagnose connectivity and credentials for a context",
+	Long: `Run an end-to-end checklist against a context and report pass, warn or fail
+for each item.
+
+Checks:
+  pocketbase.health        PocketBase is reachable (/api/health)
+  pocketbase.clock_skew    Local clock matches the PocketBase server clock
+  pocketbase.token         Session token is present, accepted and not about to expire
+  pocketbase.organization  Authenticated user belongs to the configured organization
+  nats.connect             Each NATS server accepts a connection (with RTT)
+  nats.creds_file          Credentials (or NKey seed) file exists and is not readable by others
+  nats.creds_expiry        User JWT inside the credentials file has not expired
+  nats.tls_ca              Configured CA bundle has not expired
+  nats.tls_cert            Configured client certificate has not expired
+
+If no context name is provided, the active context is checked. The command exits
+with a non-zero status if any check fails, so it can be used for monitoring.
+
+Examples:
+  flint context doctor
+  flint context doctor production
+  flint context doctor production --output json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateConfigManager(); err != nil {
+			return err
+		}
+
+		format := strings.ToLower(doctorOutputFormat)
+		if format != config.OutputFormatTable {
+			if err := utils.ValidateOutputFormat(format); err != nil {
+				return err
+			}
+			// Keep machine-readable output clean
+			config.Global.Quiet = true
+		}
+
+		var ctx *config.Context
+		var err error
+		if len(args) == 0 {
+			ctx, err = configManager.GetActiveContext()
+			if err != nil {
+				if config.IsContextNotFound(err) {
+					return err
+				}
+				return fmt.Errorf("no active context set. Use 'flint context select <n>' to set one")
+			}
+		} else {
+			ctx, err = configManager.LoadContext(args[0])
+			if err != nil {
+				return err
+			}
+		}
+
+		report := runDoctor(ctx)
+
+		if format == config.OutputFormatTable {
+			printDoctorReport(report)
+		} else if err := utils.OutputData(report, format); err != nil {
+			return err
+		}
+
+		if !report.Healthy {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d check(s) failed for context '%s'", report.Summary[checkFail], ctx.Name)
+		}
+
+		return nil
+	},
+}
+
+// runDoctor runs all checks against a context
+func runDoctor(ctx *config.Context) *DoctorReport {
+	report := &DoctorReport{
+		Context:   ctx.Name,
+		CheckedAt: time.Now().UTC(),
+		Healthy:   true,
+		Summary:   map[string]int{checkPass: 0, checkWarn: 0, checkFail: 0, checkSkip: 0},
+	}
+
+	checkPocketBase(report, ctx)
+	checkNATS(report, ctx)
+
+	return report
+}
+
+// checkPocketBase runs the PocketBase checks
+func checkPocketBase(report *DoctorReport, ctx *config.Context) {
+	resolved := *ctx
+	resolved.PocketBase = configManager.ResolvePocketBaseConfig(ctx)
+	client := pocketbase.NewClientFromContext(&resolved)
+
+	// Reachability
+	health, err := client.GetHealthInfo()
+	if err != nil {
+		report.add("pocketbase.health", checkFail, "%s unreachable: %v", ctx.PocketBase.URL, err)
+		report.add("pocketbase.clock_skew", checkSkip, "PocketBase unreachable")
+		report.add("pocketbase.token", checkSkip, "PocketBase unreachable")
+		report.add("pocketbase.organization", checkSkip, "PocketBase unreachable")
+		return
+	}
+	report.add("pocketbase.health", checkPass, "%s reachable (%s)",
+		ctx.PocketBase.URL, health.Latency.Round(time.Millisecond))
+
+	// Clock skew against the server's Date header
+	if health.ServerTime == nil {
+		report.add("pocketbase.clock_skew", checkSkip, "server did not send a Date header")
+	} else {
+		// Date has one-second resolution and was generated roughly half-way through the request
+		skew := time.Since(health.ServerTime.Add(health.Latency / 2)).Round(time.Second)
+		abs := skew
+		if abs < 0 {
+			abs = -abs
+		}
+		switch {
+		case abs >= doctorSkewFailure:
+			report.add("pocketbase.clock_skew", checkFail, "local clock is off by %s (token expiry checks will be wrong)", skew)
+		case abs >= doctorSkewWarning:
+			report.add("pocketbase.clock_skew", checkWarn, "local clock is off by %s", skew)
+		default:
+			report.add("pocketbase.clock_skew", checkPass, "clock skew %s", skew)
+		}
+	}
+
+	// Token validity
+	tokenOK := checkPocketBaseToken(report, ctx, client)
+
+	// Organization membership
+	switch {
+	case ctx.PocketBase.OrganizationID == "":
+		report.add("pocketbase.organization", checkWarn, "no organization set. Use 'flint context organization <org_id>'")
+	case !tokenOK:
+		report.add("pocketbase.organization", checkSkip, "requires a valid session")
+	default:
+		if err := client.ValidateOrganizationAccess(ctx.PocketBase.OrganizationID); err != nil {
+			report.add("pocketbase.organization", checkFail, "%v", err)
+		} else {
+			report.add("pocketbase.organization", checkPass, "member of organization %s", ctx.PocketBase.OrganizationID)
+		}
+	}
+}
+
+// checkPocketBaseToken checks the session token and returns true if it is usable
+func checkPocketBaseToken(report *DoctorReport, ctx *config.Context, client *pocketbase.Client) bool {
+	// Tokens from FLINT_PB_TOKEN or a credential helper are not stored in the context
+	if source := client.TokenSource(); source != "" {
+		if err := client.CredentialError(); err != nil {
+			report.add("pocketbase.token", checkFail, "%v", err)
+			return false
+		}
+		report.add("pocketbase.token", checkPass, "using token from %s", source)
+		return true
+	}
+
+	if ctx.PocketBase.AuthToken == "" {
+		report.add("pocketbase.token", checkFail, "not authenticated. Run 'flint auth pb'")
+		return false
+	}
+
+	var left time.Duration
+	if ctx.PocketBase.AuthExpires != nil {
+		left = time.Until(*ctx.PocketBase.AuthExpires)
+		if left <= 0 {
+			report.add("pocketbase.token", checkFail, "session expired %s ago. Run 'flint auth pb'",
+				(-left).Round(time.Minute))
+			return false
+		}
+	}
+
+	// Ask the server whether it still accepts the token
+	recordID, _ := ctx.PocketBase.AuthRecord["id"].(string)
+	if recordID != "" {
+		if _, err := client.GetRecord(ctx.PocketBase.AuthCollection, recordID, nil); err != nil {
+			report.add("pocketbase.token", checkFail, "session rejected by server: %v", err)
+			return false
+		}
+	}
+
+	switch {
+	case ctx.PocketBase.AuthExpires == nil:
+		report.add("pocketbase.token", checkPass, "session valid (no expiry recorded)")
+	case left < doctorExpiryWarning:
+		report.add("pocketbase.token", checkWarn, "session expires in %s. Run 'flint auth pb'", left.Round(time.Minute))
+	default:
+		report.add("pocketbase.token", checkPass, "session valid for %s", utils.FormatDuration(int64(left.Seconds())))
+	}
+
+	return true
+}
+
+// checkNATS runs the NATS checks
+func checkNATS(report *DoctorReport, ctx *config.Context) {
+	natsConfig := configManager.ResolveNATSConfig(ctx)
+
+	credsOK := true
+	if natsConfig.CredentialHelper != nil {
+		report.add("nats.creds_file", checkSkip, "credentials supplied by credential helper")
+		report.add("nats.creds_expiry", checkSkip, "credentials supplied by credential helper")
+	} else if natsConfig.AuthMethod == config.NATSAuthCreds {
+		credsOK = checkCredsFile(report, natsConfig.CredsFile)
+	} else if natsConfig.AuthMethod == config.NATSAuthNKey {
+		credsOK = checkNKeyFile(report, natsConfig.NKeyFile)
+	} else {
+		report.add("nats.creds_file", checkSkip, "auth method is %s", natsConfig.AuthMethod)
+		report.add("nats.creds_expiry", checkSkip, "auth method is %s", natsConfig.AuthMethod)
+	}
+
+	checkCertificateFile(report, "nats.tls_ca", "CA bundle", natsConfig.TLSCAFile)
+	checkCertificateFile(report, "nats.tls_cert", "client certificate", natsConfig.TLSCertFile)
+
+	if len(natsConfig.Servers) == 0 {
+		report.add("nats.connect", checkFail, "no NATS servers configured")
+		return
+	}
+	if !credsOK {
+		report.add("nats.connect", checkSkip, "credentials file unusable")
+		return
+	}
+
+	// Connect to each server individually so one bad server is visible
+	for _, server := range natsConfig.Servers {
+		name := fmt.Sprintf("nats.connect[%s]", server)
+
+		serverCtx := *ctx
+		serverCtx.NATS = natsConfig
+		serverCtx.NATS.Servers = []string{server}
+		client := natsClient.NewClientFromContext(&serverCtx)
+
+		if err := client.Connect(); err != nil {
+			report.add(name, checkFail, "%v", natsClient.WrapNATSError("connect", "", err))
+			continue
+		}
+
+		status := client.GetConnectionStatus()
+		client.Disconnect()
+
+		if status.RTT > doctorRTTWarning {
+			report.add(name, checkWarn, "connected to %s, RTT %s", status.ServerName, status.RTT.Round(time.Millisecond))
+		} else {
+			report.add(name, checkPass, "connected to %s, RTT %s", status.ServerName, status.RTT.Round(time.Microsecond))
+		}
+	}
+}
+
+// checkCredsFile checks the creds file and the JWT inside it; returns false if unusable
+func checkCredsFile(report *DoctorReport, path string) bool {
+	if path == "" {
+		report.add("nats.creds_file", checkFail, "no credentials file configured. Run 'flint auth nats'")
+		report.add("nats.creds_expiry", checkSkip, "no credentials file")
+		return false
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		report.add("nats.creds_file", checkFail, "%s: %v", path, err)
+		report.add("nats.creds_expiry", checkSkip, "no credentials file")
+		return false
+	}
+
+	if info.Mode().Perm()&0077 != 0 {
+		report.add("nats.creds_file", checkWarn, "%s is accessible by other users (mode %s). Run 'chmod 600 %s'",
+			path, info.Mode().Perm(), path)
+	} else {
+		report.add("nats.creds_file", checkPass, "%s (mode %s)", path, info.Mode().Perm())
+	}
+
+	expires, err := natsClient.CredsExpiry(path)
+	switch {
+	case err != nil:
+		report.add("nats.creds_expiry", checkFail, "%v", err)
+		return false
+	case expires == nil:
+		report.add("nats.creds_expiry", checkPass, "user JWT does not expire")
+	case time.Until(*expires) <= 0:
+		report.add("nats.creds_expiry", checkFail, "user JWT expired on %s", expires.Format("2006-01-02 15:04:05"))
+		return false
+	case time.Until(*expires) < doctorExpiryWarning:
+		report.add("nats.creds_expiry", checkWarn, "user JWT expires in %s", time.Until(*expires).Round(time.Minute))
+	default:
+		report.add("nats.creds_expiry", checkPass, "user JWT valid until %s", expires.Format("2006-01-02 15:04"))
+	}
+
+	return true
+}
+
+// checkCertificateFile checks that a PEM certificate file has not expired
+func checkCertificateFile(report *DoctorReport, name, what, path string) {
+	if path == "" {
+		report.add(name, checkSkip, "no %s configured", what)
+		return
+	}
+
+	expires, err := utils.CertificateExpiry(path)
+	switch {
+	case err != nil:
+		report.add(name, checkFail, "%v", err)
+	case time.Until(expires) <= 0:
+		report.add(name, checkFail, "%s expired on %s", what, expires.Format("2006-01-02 15:04:05"))
+	case time.Until(expires) < doctorExpiryWarning:
+		report.add(name, checkWarn, "%s expires in %s", what, time.Until(expires).Round(time.Minute))
+	default:
+		report.add(name, checkPass, "%s valid until %s", what, expires.Format("2006-01-02 15:04"))
+	}
+}
+
+// checkNKeyFile checks the NKey seed file. Seeds carry no expiry.
+func checkNKeyFile(report *DoctorReport, path string) bool {
+	ok := true
+	switch info, err := os.Stat(path); {
+	case path == "":
+		report.add("nats.creds_file", checkFail, "no NKey seed file configured. Run 'flint auth nats --method nkey'")
+		ok = false
+	case err != nil:
+		report.add("nats.creds_file", checkFail, "%s: %v", path, err)
+		ok = false
+	default:
+		publicKey, err := natsClient.NKeyPublicKey(path)
+		if err != nil {
+			report.add("nats.creds_file", checkFail, "%v", err)
+			ok = false
+		} else if info.Mode().Perm()&0077 != 0 {
+			report.add("nats.creds_file", checkWarn, "%s is accessible by other users (mode %s). Run 'chmod 600 %s'",
+				path, info.Mode().Perm(), path)
+		} else {
+			report.add("nats.creds_file", checkPass, "%s (mode %s, public key %s)", path, info.Mode().Perm(), publicKey)
+		}
+	}
+
+	report.add("nats.creds_expiry", checkSkip, "NKey seeds do not expire")
+	return ok
+}
+
+// printDoctorReport prints the report as a checklist
+func printDoctorReport(report *DoctorReport) {
+	bold := color.New(color.Bold).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	fmt.Printf("%s Doctor: %s\n", bold("Stone-Age.io"), cyan(report.Context))
+	fmt.Println(strings.Repeat("=", 50))
+
+	for _, check := range report.Checks {
+		fmt.Printf("%s %-34s %s\n", formatCheckStatus(check.Status), check.Name, check.Message)
+	}
+
+	fmt.Printf("\n%d passed, %d warnings, %d failed, %d skipped\n",
+		report.Summary[checkPass], report.Summary[checkWarn],
+		report.Summary[checkFail], report.Summary[checkSkip])
+}
+
+// formatCheckStatus returns a colored status label
+func formatCheckStatus(status string) string {
+	switch status {
+	case checkPass:
+		return color.New(color.FgGreen).Sprint("[PASS]")
+	case checkWarn:
+		return color.New(color.FgYellow).Sprint("[WARN]")
+	case checkFail:
+		return color.New(color.FgRed).Sprint("[FAIL]")
+	default:
+		return color.New(color.FgHiBlack).Sprint("[SKIP]")
+	}
+}
+
+func init() {
+	doctorCmd.Flags().StringVarP(&doctorOutputFormat, "output", "o", "table",
+		"Output format (table|json|yaml)")
+}
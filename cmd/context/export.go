@@ -0,0 +1,128 @@
+package context
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"flint-cli/internal/config"
+	"flint-cli/internal/secrets"
+	"flint-cli/internal/utils"
+)
+
+var (
+	exportOutput         string
+	exportKeepTokens     bool
+	exportEncrypt        bool
+	exportPassphraseFile string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <name>",
+	Short: "Export a context to a shareable bundle",
+	Long: `Export a context and its files (e.g. nats.creds, PocketBase and NATS TLS
+certificates) to a tar.gz bundle.
+
+The bundle can be handed to a teammate and installed with 'flint context import'.
+By default the PocketBase session (auth token and cached auth record), the static
+request headers and the proxy credentials are stripped so the recipient
+authenticates as themselves and supplies their own keys. Use --keep-tokens to
+keep them.
+
+NATS credentials (creds file, password or token) are included so the bundle is
+usable as-is. Use --encrypt to protect the bundle with a passphrase. The
+passphrase is read from --passphrase-file, FLINT_BUNDLE_PASSPHRASE, or prompted.
+
+Examples:
+  flint context export production -o production.tar.gz
+  flint context export production -o prod.tar.gz --encrypt
+  flint context export staging --keep-tokens --passphrase-file ~/.bundle.key --encrypt`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateConfigManager(); err != nil {
+			return err
+		}
+
+		contextName := args[0]
+		if !configManager.ContextExists(contextName) {
+			return fmt.Errorf("context '%s' not found", contextName)
+		}
+
+		outputPath := exportOutput
+		if outputPath == "" {
+			outputPath = contextName + ".tar.gz"
+		}
+
+		opts := config.ExportOptions{KeepTokens: exportKeepTokens}
+		if exportEncrypt || exportPassphraseFile != "" {
+			passphrase, err := secrets.ResolvePassphrase("Bundle passphrase", exportPassphraseFile,
+				secrets.EnvBundlePassphrase, true)
+			if err != nil {
+				return err
+			}
+			opts.Passphrase = passphrase
+		}
+
+		data, err := configManager.ExportContext(contextName, opts)
+		if err != nil {
+			return fmt.Errorf("failed to export context: %w", err)
+		}
+
+		if err := os.WriteFile(outputPath, data, 0600); err != nil {
+			return fmt.Errorf("failed to write bundle: %w", err)
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		yellow := color.New(color.FgYellow).SprintFunc()
+		cyan := color.New(color.FgCyan).SprintFunc()
+
+		fmt.Printf("%s Context '%s' exported to %s\n", green("✓"), cyan(contextName), outputPath)
+		if opts.Passphrase != nil {
+			fmt.Printf("  Encrypted:      %s\n", green("Yes"))
+		} else {
+			fmt.Printf("  Encrypted:      %s\n", yellow("No - the bundle contains NATS credentials, share it securely"))
+		}
+		if exportKeepTokens {
+			fmt.Printf("  Session Tokens: %s\n", yellow("Included"))
+		} else {
+			fmt.Printf("  Session Tokens: Stripped\n")
+		}
+
+		warnMissingContextFiles(contextName)
+
+		fmt.Printf("\nImport with: %s\n", cyan(fmt.Sprintf("flint context import %s", outputPath)))
+
+		return nil
+	},
+}
+
+// warnMissingContextFiles warns about files a context refers to in its
+// directory that do not exist, since the bundle cannot include them
+func warnMissingContextFiles(contextName string) {
+	ctx, err := configManager.LoadContext(contextName)
+	if err != nil {
+		return
+	}
+
+	for _, path := range append(ctx.NATS.FilePaths(), ctx.PocketBase.FilePaths()...) {
+		if !strings.HasPrefix(*path, "./") {
+			continue
+		}
+		if _, err := os.Stat(configManager.ResolveContextPath(contextName, *path)); os.IsNotExist(err) {
+			utils.PrintWarning(fmt.Sprintf("The context refers to '%s', which does not exist, so the bundle does not include it", *path))
+		}
+	}
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "",
+		"Bundle file to write (default: <name>.tar.gz)")
+	exportCmd.Flags().BoolVar(&exportKeepTokens, "keep-tokens", false,
+		"Keep the PocketBase session, request headers and proxy credentials in the bundle")
+	exportCmd.Flags().BoolVar(&exportEncrypt, "encrypt", false,
+		"Encrypt the bundle with a passphrase")
+	exportCmd.Flags().StringVar(&exportPassphraseFile, "passphrase-file", "",
+		"File containing the bundle passphrase (implies --encrypt)")
+}
@@ -0,0 +1,187 @@
+package context
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"flint-cli/internal/config"
+	"flint-cli/internal/secrets"
+	"flint-cli/internal/utils"
+)
+
+var (
+	importAs             string
+	importForce          bool
+	importStripTokens    bool
+	importPassphraseFile string
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <bundle>",
+	Short: "Import a context from a bundle",
+	Long: `Import a context from a bundle created with 'flint context export'.
+
+The context is created under its original name, or under --as <name>. The
+bundled files (e.g. nats.creds) are written into the new context directory and
+relative creds_file paths are pointed at them. The PocketBase URL and NATS
+servers are validated before anything is written.
+
+Encrypted bundles are detected automatically. The passphrase is read from
+--passphrase-file, FLINT_BUNDLE_PASSPHRASE, or prompted.
+
+Examples:
+  flint context import production.tar.gz
+  flint context import production.tar.gz --as prod-readonly
+  flint context import prod.tar.gz --force         # Overwrite an existing context
+  flint context import prod.tar.gz --strip-tokens  # Drop any bundled session`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateConfigManager(); err != nil {
+			return err
+		}
+
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read bundle: %w", err)
+		}
+
+		var passphrase []byte
+		if config.IsEncryptedBundle(data) {
+			passphrase, err = secrets.ResolvePassphrase("Bundle passphrase", importPassphraseFile,
+				secrets.EnvBundlePassphrase, false)
+			if err != nil {
+				return err
+			}
+		}
+
+		bundle, err := config.ReadContextBundle(data, passphrase)
+		if err != nil {
+			return err
+		}
+
+		contextName := bundle.Context.Name
+		if importAs != "" {
+			contextName = importAs
+		}
+		if err := utils.ValidateContextName(contextName); err != nil {
+			return err
+		}
+
+		if configManager.ContextExists(contextName) && !importForce {
+			return fmt.Errorf("context '%s' already exists. Use --as <name> to import under a different name or --force to overwrite",
+				contextName)
+		}
+
+		// Validate the bundled configuration before writing anything
+		ctx := bundle.Context
+		if err := utils.ValidateURL(ctx.PocketBase.URL); err != nil {
+			return fmt.Errorf("invalid PocketBase URL in bundle: %w", err)
+		}
+		if err := utils.ValidateNATSServers(ctx.NATS.Servers); err != nil {
+			return fmt.Errorf("invalid NATS servers in bundle: %w", err)
+		}
+		rewriteBundleCredsPath(bundle, configManager.GetContextDir(contextName))
+
+		if importStripTokens {
+			ctx.PocketBase.AuthToken = ""
+			ctx.PocketBase.AuthExpires = nil
+			ctx.PocketBase.AuthRecord = nil
+		}
+
+		if err := configManager.InstallContextBundle(bundle, contextName, importForce); err != nil {
+			return fmt.Errorf("failed to import context: %w", err)
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		cyan := color.New(color.FgCyan).SprintFunc()
+
+		fmt.Printf("%s Context '%s' imported to %s\n", green("✓"), cyan(contextName),
+			configManager.GetContextDir(contextName))
+		fmt.Printf("  PocketBase URL: %s\n", ctx.PocketBase.URL)
+		fmt.Printf("  NATS Servers:   %s\n", strings.Join(ctx.NATS.Servers, ", "))
+		if len(bundle.Files) > 0 {
+			var files []string
+			for name := range bundle.Files {
+				files = append(files, name)
+			}
+			sort.Strings(files)
+			fmt.Printf("  Files:          %s\n", strings.Join(files, ", "))
+		}
+
+		fmt.Printf("\nNext steps:\n")
+		fmt.Printf("  Select context: %s\n", cyan(fmt.Sprintf("flint context select %s", contextName)))
+		if ctx.PocketBase.AuthToken == "" {
+			fmt.Printf("  Authenticate:   %s\n", cyan("flint auth pb"))
+		}
+
+		return nil
+	},
+}
+
+// rewriteBundleCredsPath points the NATS and PocketBase file paths at the bundled
+// files, warning about files the bundle lacks. contextDir is where the context is installed.
+func rewriteBundleCredsPath(bundle *config.ContextBundle, contextDir string) {
+	nats := &bundle.Context.NATS
+	pb := &bundle.Context.PocketBase
+
+	files := []struct {
+		path     *string
+		what     string
+		required bool
+	}{
+		{&nats.CredsFile, "credentials file", nats.AuthMethod == config.NATSAuthCreds},
+		{&nats.NKeyFile, "NKey seed file", nats.AuthMethod == config.NATSAuthNKey},
+		{&nats.TLSCAFile, "TLS CA bundle", nats.TLSEnabled},
+		{&nats.TLSCertFile, "TLS client certificate", nats.TLSEnabled},
+		{&nats.TLSKeyFile, "TLS client key", nats.TLSEnabled},
+		{&pb.TLSCAFile, "PocketBase TLS CA bundle", true},
+		{&pb.TLSCertFile, "PocketBase TLS client certificate", true},
+		{&pb.TLSKeyFile, "PocketBase TLS client key", true},
+	}
+	for _, file := range files {
+		rewriteBundlePath(bundle, file.path, file.what, file.required, contextDir)
+	}
+}
+
+// rewriteBundlePath points path at the bundled file of the same name. A
+// missing file is only reported when the auth method actually uses it; the
+// context is still imported, since the exported context may never have had it
+// (e.g. a creds file not yet added after 'context create').
+func rewriteBundlePath(bundle *config.ContextBundle, path *string, what string, required bool, contextDir string) {
+	if *path == "" {
+		return
+	}
+
+	base := filepath.Base(*path)
+	if _, ok := bundle.Files[base]; ok {
+		*path = "./" + base
+		return
+	}
+
+	if !required {
+		return
+	}
+	if strings.HasPrefix(*path, "./") {
+		utils.PrintWarning(fmt.Sprintf("The bundle references %s '%s' but does not contain it. Copy it to %s before connecting.",
+			what, *path, contextDir))
+		return
+	}
+	utils.PrintWarning(fmt.Sprintf("The %s '%s' is not part of the bundle. Make sure it exists on this machine.",
+		what, *path))
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importAs, "as", "",
+		"Import the context under a different name")
+	importCmd.Flags().BoolVarP(&importForce, "force", "f", false,
+		"Overwrite an existing context with the same name")
+	importCmd.Flags().BoolVar(&importStripTokens, "strip-tokens", false,
+		"Discard any PocketBase session included in the bundle")
+	importCmd.Flags().StringVar(&importPassphraseFile, "passphrase-file", "",
+		"File containing the bundle passphrase")
+}
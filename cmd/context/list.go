@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"flint-cli/internal/config"
+	"flint-cli/internal/utils"
 )
 
 var listCmd = &cobra.Command{
@@ -21,9 +23,13 @@ The currently active context is highlighted with an asterisk (*).
 Each context is stored in its own directory within the flint configuration directory,
 containing the context configuration file and any related files like NATS credentials.
 
+With --output the contexts are printed as data instead of a table (json, yaml,
+ndjson, csv or tsv), one record per context.
+
 Examples:
   flint context list
-  flint context ls`,
+  flint context ls
+  flint context list --output csv`,
 	Aliases: []string{"ls"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := validateConfigManager(); err != nil {
@@ -36,6 +42,19 @@ Examples:
 			return fmt.Errorf("failed to list contexts: %w", err)
 		}
 
+		// The table is the default; an explicit --output prints the contexts as data
+		format := strings.ToLower(config.Global.OutputFormat)
+		if cmd.Flags().Changed("output") && format != config.OutputFormatTable {
+			if err := utils.ValidateOutputFormat(format); err != nil {
+				return err
+			}
+			activeContext, _, err := configManager.GetActiveContextName()
+			if err != nil {
+				return err
+			}
+			return utils.OutputData(contextRecords(contexts, activeContext), format)
+		}
+
 		if len(contexts) == 0 {
 			fmt.Printf("No contexts configured in %s.\n", configManager.GetConfigDir())
 			fmt.Printf("\nCreate your first context:\n  %s\n", 
@@ -43,19 +62,26 @@ Examples:
 			return nil
 		}
 
-		// Get active context
-		globalConfig, err := configManager.LoadGlobalConfig()
+		// Get active context (honours --context and FLINT_CONTEXT)
+		activeContext, source, err := configManager.GetActiveContextName()
 		if err != nil {
-			return fmt.Errorf("failed to load global config: %w", err)
+			return err
 		}
 
 		// Process contexts and display
-		displayContextsTable(contexts, globalConfig.ActiveContext)
+		displayContextsTable(contexts, activeContext)
 
 		// Show active context summary
-		if globalConfig.ActiveContext != "" {
-			fmt.Printf("\nActive context: %s\n", 
-				color.New(color.FgCyan).Sprint(globalConfig.ActiveContext))
+		if activeContext != "" {
+			fmt.Printf("\nActive context: %s", 
+				color.New(color.FgCyan).Sprint(activeContext))
+			if source != config.ContextSourceConfig {
+				fmt.Printf(" (from %s)", source)
+			}
+			if !configManager.ContextExists(activeContext) {
+				fmt.Printf(" %s", color.New(color.FgRed).Sprint("(not found)"))
+			}
+			fmt.Println()
 		} else {
 			fmt.Printf("\nNo active context set. Use %s to select one.\n", 
 				color.New(color.FgCyan).Sprint("flint context select <n>"))
@@ -65,6 +91,36 @@ Examples:
 	},
 }
 
+// contextRecords returns one plain record per context for machine-readable output
+func contextRecords(contextNames []string, activeContext string) []map[string]interface{} {
+	records := make([]map[string]interface{}, 0, len(contextNames))
+	for _, name := range contextNames {
+		record := map[string]interface{}{
+			"name":   name,
+			"active": name == activeContext,
+		}
+
+		ctx, err := configManager.LoadContext(name)
+		if err != nil {
+			record["error"] = err.Error()
+			records = append(records, record)
+			continue
+		}
+
+		record["authenticated"] = ctx.PocketBase.AuthToken != ""
+		record["pocketbase_url"] = ctx.PocketBase.URL
+		record["organization_id"] = ctx.PocketBase.OrganizationID
+		record["nats_servers"] = strings.Join(ctx.NATS.Servers, ",")
+		record["nats_auth_method"] = ctx.NATS.AuthMethod
+		record["auth_expires"] = ""
+		if ctx.PocketBase.AuthExpires != nil {
+			record["auth_expires"] = ctx.PocketBase.AuthExpires.Format(time.RFC3339)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
 // ContextDisplayInfo holds processed context information for display
 type ContextDisplayInfo struct {
 	Name          string
@@ -0,0 +1,105 @@
+package context
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"flint-cli/internal/config"
+)
+
+var migrateKeyFile string
+
+var migrateSecretsCmd = &cobra.Command{
+	Use:   "migrate-secrets [name...]",
+	Short: "Move plaintext context secrets into the encrypted vault",
+	Long: `Move sensitive values out of context.yaml into an encrypted per-context vault.
+
+The PocketBase auth token and auth record, the NATS password and the NATS token
+are removed from context.yaml and written to secrets.vault in the context
+directory. The vault is encrypted with a key derived from a passphrase, or
+read from a key file for non-interactive use (CI).
+
+After migration the global configuration is switched to the vault secret store,
+so all future logins and context updates keep secrets out of context.yaml.
+
+If no context names are given, all contexts are migrated.
+
+Passphrase sources (first match wins):
+  FLINT_VAULT_KEY_FILE      Path to a key file
+  --key-file / vault_key_file in config.yaml
+  FLINT_VAULT_PASSPHRASE    Passphrase value
+  Interactive prompt
+
+Examples:
+  flint context migrate-secrets                          # Migrate all contexts
+  flint context migrate-secrets production staging       # Migrate specific contexts
+  flint context migrate-secrets --key-file ~/.flint.key  # Use a key file`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateConfigManager(); err != nil {
+			return err
+		}
+
+		globalConfig, err := configManager.LoadGlobalConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load global config: %w", err)
+		}
+
+		contextNames := args
+		if len(contextNames) == 0 {
+			contextNames, err = configManager.ListContexts()
+			if err != nil {
+				return fmt.Errorf("failed to list contexts: %w", err)
+			}
+		}
+
+		// Load everything before switching stores so plaintext values are captured
+		contexts := make([]*config.Context, 0, len(contextNames))
+		for _, name := range contextNames {
+			ctx, err := configManager.LoadContext(name)
+			if err != nil {
+				return fmt.Errorf("failed to load context '%s': %w", name, err)
+			}
+			contexts = append(contexts, ctx)
+		}
+
+		// Switch to the vault store
+		if migrateKeyFile != "" {
+			absPath, err := filepath.Abs(migrateKeyFile)
+			if err != nil {
+				return fmt.Errorf("invalid key file path: %w", err)
+			}
+			globalConfig.VaultKeyFile = absPath
+		}
+		globalConfig.SecretStore = config.SecretStoreVault
+
+		if err := configManager.ConfigureSecretStore(globalConfig); err != nil {
+			return err
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		cyan := color.New(color.FgCyan).SprintFunc()
+
+		for _, ctx := range contexts {
+			if err := configManager.SaveContext(ctx); err != nil {
+				return fmt.Errorf("failed to migrate context '%s': %w", ctx.Name, err)
+			}
+			fmt.Printf("%s Migrated secrets for context '%s'\n", green("✓"), cyan(ctx.Name))
+		}
+
+		if err := configManager.SaveGlobalConfig(globalConfig); err != nil {
+			return fmt.Errorf("failed to save global config: %w", err)
+		}
+
+		fmt.Printf("\nSecret store set to %s. Context secrets are now kept in %s.\n",
+			cyan(config.SecretStoreVault), cyan("secrets.vault"))
+
+		return nil
+	},
+}
+
+func init() {
+	migrateSecretsCmd.Flags().StringVar(&migrateKeyFile, "key-file", "",
+		"Key file used to encrypt the vault instead of a passphrase")
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"flint-cli/internal/config"
 	"flint-cli/internal/pocketbase"
 	"flint-cli/internal/utils"
 )
@@ -44,20 +45,25 @@ Note: You must be authenticated with PocketBase before setting an organization.`
 		// Get the active context
 		ctx, err := configManager.GetActiveContext()
 		if err != nil {
+			if config.IsContextNotFound(err) {
+				return err
+			}
 			return fmt.Errorf("no active context set. Use 'flint context select <n>' to set one")
 		}
 
 		utils.PrintDebug(fmt.Sprintf("Active context: %s", ctx.Name))
 
 		// Check if user is authenticated
-		if ctx.PocketBase.AuthToken == "" {
+		if !pocketbase.HasSession(ctx) {
 			return fmt.Errorf("authentication required. Run 'flint auth pb' to authenticate first")
 		}
 
 		// If authenticated, validate organization access with PocketBase
 		if pocketbase.IsAuthValid(ctx) {
 			utils.PrintInfo("Validating organization access...")
-			client := pocketbase.NewClientFromContext(ctx)
+			resolved := *ctx
+			resolved.PocketBase = configManager.ResolvePocketBaseConfig(ctx)
+			client := pocketbase.NewClientFromContext(&resolved)
 			if err := client.ValidateOrganizationAccess(organizationID); err != nil {
 				if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
 					utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
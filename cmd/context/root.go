@@ -22,7 +22,9 @@ Context Directory Structure:
   ├── config.yaml           # Global configuration
   ├── production/           # Production context directory
   │   ├── context.yaml     # Context configuration
-  │   └── nats.creds       # NATS credentials (if using creds auth)
+  │   ├── secrets.vault    # Encrypted secrets (if using the vault store)
+  │   ├── nats.creds       # NATS credentials (if using creds auth)
+  │   └── nats.nk          # NATS NKey seed (if using nkey auth)
   ├── development/          # Development context directory
   │   ├── context.yaml
   │   └── nats.creds
@@ -35,7 +37,13 @@ Examples:
   flint context select production
   flint context organization org_abc123
   flint context list
-  flint context show production`,
+  flint context show production
+  flint context migrate-secrets
+  flint context export production -o production.tar.gz
+  flint context import production.tar.gz --as prod
+  flint context clone production staging --pb-url https://staging.stone-age.io
+  flint context rename dev development
+  flint context doctor`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Show usage instead of full help when no subcommand provided
 		return fmt.Errorf("missing subcommand. See 'flint context --help' for available commands")
@@ -52,6 +60,12 @@ func init() {
 	ContextCmd.AddCommand(showCmd)
 	ContextCmd.AddCommand(deleteCmd)
 	ContextCmd.AddCommand(organizationCmd)
+	ContextCmd.AddCommand(migrateSecretsCmd)
+	ContextCmd.AddCommand(exportCmd)
+	ContextCmd.AddCommand(importCmd)
+	ContextCmd.AddCommand(cloneCmd)
+	ContextCmd.AddCommand(renameCmd)
+	ContextCmd.AddCommand(doctorCmd)
 }
 
 // SetConfigManager sets the configuration manager for the context commands
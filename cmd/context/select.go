@@ -2,11 +2,15 @@ package context
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+var selectShell bool
+
 var selectCmd = &cobra.Command{
 	Use:   "select <n>",
 	Short: "Set the active Stone-Age.io context",
@@ -15,9 +19,18 @@ var selectCmd = &cobra.Command{
 The active context determines which PocketBase instance, NATS servers,
 and organization settings are used for all flint commands.
 
+The active context is stored in config.yaml and shared by every terminal.
+To use a different context in a single shell session, use --shell to print
+an export line for FLINT_CONTEXT instead of changing config.yaml:
+
+  eval "$(flint context use --shell staging)"
+
+A single command can also target a context with the global --context flag.
+
 Examples:
   flint context select production
   flint context select development
+  flint context use --shell staging  # Print 'export FLINT_CONTEXT=staging'
   flint con sel prod  # Using partial matching`,
 	Aliases: []string{"use", "switch"},
 	Args:    cobra.ExactArgs(1),
@@ -31,6 +44,10 @@ Examples:
 		// Verify the context exists
 		ctx, err := configManager.LoadContext(contextName)
 		if err != nil {
+			// Context exists but could not be loaded (e.g. vault locked)
+			if configManager.ContextExists(contextName) {
+				return err
+			}
 			// Try to provide helpful suggestions
 			contexts, listErr := configManager.ListContexts()
 			if listErr == nil && len(contexts) > 0 {
@@ -40,6 +57,12 @@ Examples:
 			return fmt.Errorf("context '%s' not found", contextName)
 		}
 
+		// Print a shell export line instead of changing the global config
+		if selectShell {
+			fmt.Println(shellExportLine("FLINT_CONTEXT", ctx.Name))
+			return nil
+		}
+
 		// Set as active context
 		if err := configManager.SetActiveContext(contextName); err != nil {
 			return fmt.Errorf("failed to set active context: %w", err)
@@ -96,3 +119,16 @@ Examples:
 		return nil
 	},
 }
+
+// shellExportLine returns a command that sets an environment variable in the user's shell
+func shellExportLine(name, value string) string {
+	if filepath.Base(os.Getenv("SHELL")) == "fish" {
+		return fmt.Sprintf("set -gx %s %s", name, value)
+	}
+	return fmt.Sprintf("export %s=%s", name, value)
+}
+
+func init() {
+	selectCmd.Flags().BoolVar(&selectShell, "shell", false,
+		"Print an export line for FLINT_CONTEXT instead of changing the active context")
+}
@@ -3,11 +3,16 @@ package context
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"flint-cli/internal/config"
+	natsClient "flint-cli/internal/nats"
+	"flint-cli/internal/pocketbase"
+	"flint-cli/internal/utils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -22,7 +27,9 @@ If no context name is provided, shows the currently active context.
 The output format can be controlled with the --output flag.
 
 The context information includes the directory location, configuration details,
-and paths to related files like NATS credentials.
+and paths to related files like NATS credentials. It also reports where each
+effective setting came from (--context flag, FLINT_CONTEXT, .flint.yaml or
+config.yaml).
 
 Examples:
   flint context show                    # Show active context
@@ -43,6 +50,9 @@ Examples:
 			// Show active context
 			ctx, err = configManager.GetActiveContext()
 			if err != nil {
+				if config.IsContextNotFound(err) {
+					return err
+				}
 				return fmt.Errorf("no active context set. Use 'flint context select <n>' to set one")
 			}
 			contextName = ctx.Name
@@ -51,6 +61,10 @@ Examples:
 			contextName = args[0]
 			ctx, err = configManager.LoadContext(contextName)
 			if err != nil {
+				// Context exists but could not be loaded (e.g. vault locked)
+				if configManager.ContextExists(contextName) {
+					return err
+				}
 				// Try to provide helpful suggestions
 				contexts, listErr := configManager.ListContexts()
 				if listErr == nil && len(contexts) > 0 {
@@ -62,18 +76,27 @@ Examples:
 		}
 
 		// Check if it's the active context
-		globalConfig, err := configManager.LoadGlobalConfig()
+		activeContext, activeSource, err := configManager.GetActiveContextName()
 		if err != nil {
-			return fmt.Errorf("failed to load global config: %w", err)
+			return err
 		}
 
-		isActive := globalConfig.ActiveContext == contextName
+		isActive := activeContext == contextName
+		sources := collectSettingSources(ctx, isActive, activeSource)
 
 		// Create a display version of the context (hide sensitive data)
 		displayCtx := *ctx
 		if displayCtx.PocketBase.AuthToken != "" {
 			displayCtx.PocketBase.AuthToken = "***HIDDEN***"
 		}
+		if len(displayCtx.PocketBase.Headers) > 0 {
+			headers := make(map[string]string, len(displayCtx.PocketBase.Headers))
+			for name := range displayCtx.PocketBase.Headers {
+				headers[name] = "***HIDDEN***"
+			}
+			displayCtx.PocketBase.Headers = headers
+		}
+		displayCtx.PocketBase.ProxyURL = pocketbase.RedactURL(displayCtx.PocketBase.ProxyURL)
 		if displayCtx.NATS.Password != "" {
 			displayCtx.NATS.Password = "***HIDDEN***"
 		}
@@ -84,14 +107,14 @@ Examples:
 		// Output based on format
 		switch strings.ToLower(showOutputFormat) {
 		case "json":
-			output, err := json.MarshalIndent(displayCtx, "", "  ")
+			output, err := json.MarshalIndent(contextWithSources{displayCtx, sources}, "", "  ")
 			if err != nil {
 				return fmt.Errorf("failed to marshal context to JSON: %w", err)
 			}
 			fmt.Println(string(output))
 
 		case "yaml":
-			output, err := yaml.Marshal(displayCtx)
+			output, err := yaml.Marshal(contextWithSources{displayCtx, sources})
 			if err != nil {
 				return fmt.Errorf("failed to marshal context to YAML: %w", err)
 			}
@@ -99,7 +122,7 @@ Examples:
 
 		case "table", "":
 			// Default table format
-			showContextTable(ctx, isActive, configManager)
+			showContextTable(ctx, isActive, configManager, sources)
 
 		default:
 			return fmt.Errorf("invalid output format '%s'. Valid formats: json, yaml, table", 
@@ -110,7 +133,66 @@ Examples:
 	},
 }
 
-func showContextTable(ctx *config.Context, isActive bool, configManager *config.Manager) {
+// contextWithSources adds effective setting sources to context output
+type contextWithSources struct {
+	config.Context `yaml:",inline"`
+	Sources        map[string]string `json:"sources" yaml:"sources"`
+}
+
+// collectSettingSources reports where each effective setting for a context came from
+func collectSettingSources(ctx *config.Context, isActive bool, activeSource string) map[string]string {
+	sources := map[string]string{
+		config.SettingOrganization: configManager.OrganizationSource(ctx),
+	}
+
+	if isActive {
+		sources[config.SettingContext] = activeSource
+	}
+
+	if source := configManager.GetSettingSource(config.SettingOutputFormat); source != "" {
+		sources[config.SettingOutputFormat] = source
+	}
+
+	if project, _ := configManager.GetProjectConfig(); project != nil && len(project.Collections) > 0 {
+		sources[config.SettingCollections] = configManager.ProjectSource()
+	}
+
+	return sources
+}
+
+// showSettingSources prints where the effective settings came from
+func showSettingSources(ctx *config.Context, sources map[string]string) {
+	bold := color.New(color.Bold).SprintFunc()
+	faint := color.New(color.FgHiBlack).SprintFunc()
+
+	fmt.Printf("%s\n", bold("Effective Settings:"))
+	if source, ok := sources[config.SettingContext]; ok {
+		fmt.Printf("  Context:            %-24s %s\n", ctx.Name, faint("from "+source))
+	}
+
+	orgID := ctx.PocketBase.OrganizationID
+	if orgID == "" {
+		orgID = "Not Set"
+	}
+	fmt.Printf("  Organization ID:    %-24s %s\n", orgID, faint("from "+sources[config.SettingOrganization]))
+
+	if source, ok := sources[config.SettingOutputFormat]; ok {
+		fmt.Printf("  Output Format:      %-24s %s\n", config.Global.OutputFormat, faint("from "+source))
+	}
+
+	if source, ok := sources[config.SettingCollections]; ok {
+		project, _ := configManager.GetProjectConfig()
+		names := make([]string, 0, len(project.Collections))
+		for name := range project.Collections {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Printf("  Collection Defaults: %-23s %s\n", strings.Join(names, ", "), faint("from "+source))
+	}
+	fmt.Println()
+}
+
+func showContextTable(ctx *config.Context, isActive bool, configManager *config.Manager, sources map[string]string) {
 	green := color.New(color.FgGreen).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
 	cyan := color.New(color.FgCyan).SprintFunc()
@@ -126,7 +208,17 @@ func showContextTable(ctx *config.Context, isActive bool, configManager *config.
 
 	// Show context directory
 	contextDir := configManager.GetContextDir(ctx.Name)
-	fmt.Printf("Context Directory: %s\n\n", contextDir)
+	fmt.Printf("Context Directory: %s\n", contextDir)
+
+	// Show where secrets are kept
+	storeName := configManager.SecretStoreName()
+	fmt.Printf("Secret Storage:    %s", storeName)
+	if storeName == config.SecretStoreVault {
+		if plaintext, err := configManager.HasPlaintextSecrets(ctx.Name); err == nil && plaintext {
+			fmt.Printf(" %s", yellow("(plaintext secrets pending - run 'flint context migrate-secrets')"))
+		}
+	}
+	fmt.Printf("\n\n")
 
 	// PocketBase Configuration
 	fmt.Printf("%s\n", bold("PocketBase Configuration:"))
@@ -139,6 +231,37 @@ func showContextTable(ctx *config.Context, isActive bool, configManager *config.
 		fmt.Printf("  Organization ID:    %s\n", yellow("Not Set"))
 	}
 
+	// Transport settings
+	pbFiles := configManager.ResolvePocketBaseConfig(ctx)
+	if pbFiles.TLSCAFile != "" {
+		fmt.Printf("  TLS CA Bundle:      %s\n", pbFiles.TLSCAFile)
+		fmt.Printf("    Expires:          %s\n", describeCertificateExpiry(pbFiles.TLSCAFile))
+	}
+	if pbFiles.TLSCertFile != "" {
+		fmt.Printf("  TLS Client Cert:    %s\n", pbFiles.TLSCertFile)
+		fmt.Printf("    Expires:          %s\n", describeCertificateExpiry(pbFiles.TLSCertFile))
+	}
+	if pbFiles.TLSKeyFile != "" {
+		fmt.Printf("  TLS Client Key:     %s\n", pbFiles.TLSKeyFile)
+	}
+	if ctx.PocketBase.TLSInsecureSkipVerify {
+		fmt.Printf("  TLS Verify:         %s\n", yellow("Disabled"))
+	}
+	if ctx.PocketBase.ProxyURL != "" {
+		fmt.Printf("  Proxy:              %s\n", pocketbase.RedactURL(ctx.PocketBase.ProxyURL))
+	}
+	if ctx.PocketBase.Timeout != "" {
+		fmt.Printf("  Request Timeout:    %s\n", ctx.PocketBase.Timeout)
+	}
+	if len(ctx.PocketBase.Headers) > 0 {
+		names := make([]string, 0, len(ctx.PocketBase.Headers))
+		for name := range ctx.PocketBase.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Printf("  Headers:            %s\n", strings.Join(names, ", "))
+	}
+
 	// Authentication status
 	if ctx.PocketBase.AuthToken != "" {
 		if ctx.PocketBase.AuthExpires != nil {
@@ -202,6 +325,39 @@ func showContextTable(ctx *config.Context, isActive bool, configManager *config.
 		} else {
 			fmt.Printf("  Credentials File:   %s\n", yellow("Not Set"))
 		}
+
+	case config.NATSAuthNKey:
+		if ctx.NATS.NKeyFile != "" {
+			seedPath := configManager.ResolveContextPath(ctx.Name, ctx.NATS.NKeyFile)
+			fmt.Printf("  NKey Seed File:     %s\n", seedPath)
+			if publicKey, err := natsClient.NKeyPublicKey(seedPath); err == nil {
+				fmt.Printf("  Public Key:         %s\n", publicKey)
+			} else {
+				fmt.Printf("  Public Key:         %s\n", yellow("Unreadable"))
+			}
+		} else {
+			fmt.Printf("  NKey Seed File:     %s\n", yellow("Not Set"))
+		}
+	}
+
+	// TLS details, with certificate expiry
+	resolved := configManager.ResolveNATSConfig(ctx)
+	if ctx.NATS.TLSCAFile != "" {
+		fmt.Printf("  TLS CA Bundle:      %s\n", resolved.TLSCAFile)
+		fmt.Printf("    Expires:          %s\n", describeCertificateExpiry(resolved.TLSCAFile))
+	}
+	if ctx.NATS.TLSCertFile != "" {
+		fmt.Printf("  TLS Client Cert:    %s\n", resolved.TLSCertFile)
+		fmt.Printf("    Expires:          %s\n", describeCertificateExpiry(resolved.TLSCertFile))
+	}
+	if ctx.NATS.TLSKeyFile != "" {
+		fmt.Printf("  TLS Client Key:     %s\n", resolved.TLSKeyFile)
+	}
+	if ctx.NATS.TLSServerName != "" {
+		fmt.Printf("  TLS Server Name:    %s\n", ctx.NATS.TLSServerName)
+	}
+	if ctx.NATS.TLSMinVersion != "" {
+		fmt.Printf("  TLS Min Version:    %s\n", ctx.NATS.TLSMinVersion)
 	}
 
 	fmt.Println()
@@ -228,6 +384,9 @@ func showContextTable(ctx *config.Context, isActive bool, configManager *config.
 		}
 	}
 
+	// Where the effective settings came from
+	showSettingSources(ctx, sources)
+
 	// Show helpful commands
 	if !isActive {
 		fmt.Printf("%s\n", bold("Commands:"))
@@ -246,3 +405,29 @@ func init() {
 	showCmd.Flags().StringVarP(&showOutputFormat, "output", "o", "table", 
 		"Output format (table|json|yaml)")
 }
+
+// certificateWarning is how close to expiry a certificate is highlighted
+const certificateWarning = 30 * 24 * time.Hour
+
+// describeCertificateExpiry reports when the certificates in a PEM file expire
+func describeCertificateExpiry(path string) string {
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	expires, err := utils.CertificateExpiry(path)
+	if err != nil {
+		return red(err.Error())
+	}
+
+	left := time.Until(expires)
+	description := fmt.Sprintf("%s (in %s)", expires.Local().Format("2006-01-02 15:04"),
+		utils.FormatDuration(int64(left.Seconds())))
+	switch {
+	case left <= 0:
+		return red(fmt.Sprintf("expired on %s", expires.Local().Format("2006-01-02 15:04")))
+	case left < certificateWarning:
+		return yellow(description)
+	default:
+		return description
+	}
+}
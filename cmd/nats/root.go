@@ -2,11 +2,11 @@ package nats
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 	"flint-cli/internal/config"
 	natsClient "flint-cli/internal/nats"
+	"flint-cli/internal/utils"
 )
 
 // NATSCmd represents the nats command group
@@ -22,6 +22,7 @@ The NATS commands support multiple authentication methods:
   user_pass  Username and password authentication
   creds      JWT credentials file authentication  
   token      Token-based authentication
+  nkey       NKey seed authentication (no JWT)
 
 Authentication is configured per context and uses the settings from your
 active context configuration.
@@ -88,6 +89,9 @@ func validateActiveContext() (*config.Context, error) {
 
 	ctx, err := configManager.GetActiveContext()
 	if err != nil {
+		if config.IsContextNotFound(err) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("no active context set. Use 'flint context select <name>' to set one")
 	}
 
@@ -105,34 +109,34 @@ func validateNATSConfig(natsConfig *config.NATSConfig) error {
 		return fmt.Errorf("no NATS servers configured. Add servers to your context with 'flint context create' or update your context configuration")
 	}
 
-	// Validate auth method
-	validMethods := []string{config.NATSAuthUserPass, config.NATSAuthToken, config.NATSAuthCreds}
-	validMethod := false
-	for _, method := range validMethods {
-		if natsConfig.AuthMethod == method {
-			validMethod = true
-			break
-		}
+	// Credentials come from the helper at connect time
+	if natsConfig.CredentialHelper != nil {
+		return nil
 	}
-	
-	if !validMethod {
-		return fmt.Errorf("invalid NATS auth method '%s'. Valid methods: user_pass, token, creds", natsConfig.AuthMethod)
+
+	// Validate auth method
+	if err := utils.ValidateNATSAuthMethod(natsConfig.AuthMethod); err != nil {
+		return err
 	}
 
 	// Validate auth-specific configuration
 	switch natsConfig.AuthMethod {
 	case config.NATSAuthUserPass:
-		if natsConfig.Username == "" || natsConfig.Password == "" {
-			return fmt.Errorf("username and password are required for user_pass authentication. Configure with 'flint auth nats'")
+		if natsConfig.Username == "" || natsClient.Password(natsConfig) == "" {
+			return fmt.Errorf("username and password are required for user_pass authentication. Configure with 'flint auth nats' or set %s", natsClient.EnvPassword)
 		}
 	case config.NATSAuthToken:
-		if natsConfig.Token == "" {
-			return fmt.Errorf("token is required for token authentication. Configure with 'flint auth nats'")
+		if natsClient.Token(natsConfig) == "" {
+			return fmt.Errorf("token is required for token authentication. Configure with 'flint auth nats' or set %s", natsClient.EnvToken)
 		}
 	case config.NATSAuthCreds:
 		if natsConfig.CredsFile == "" {
 			return fmt.Errorf("credentials file is required for creds authentication. Configure with 'flint auth nats' or place file in context directory")
 		}
+	case config.NATSAuthNKey:
+		if natsConfig.NKeyFile == "" {
+			return fmt.Errorf("NKey seed file is required for nkey authentication. Configure with 'flint auth nats --method nkey'")
+		}
 	}
 
 	return nil
@@ -150,17 +154,11 @@ func createNATSClient() (*natsClient.Client, error) {
 
 // createNATSClientFromContext creates a NATS client from a context
 func createNATSClientFromContext(ctx *config.Context) *natsClient.Client {
-	// We need to resolve the relative creds file path if present
-	natsConfig := ctx.NATS
-	
-	// Convert relative creds file path to absolute if needed
-	if natsConfig.CredsFile != "" && strings.HasPrefix(natsConfig.CredsFile, "./") {
-		contextDir := configManager.GetContextDir(ctx.Name)
-		// Replace "./" with the context directory path
-		natsConfig.CredsFile = strings.Replace(natsConfig.CredsFile, "./", contextDir+"/", 1)
-	}
-	
-	return natsClient.NewClient(&natsConfig)
+	// Resolve file paths relative to the context directory
+	resolved := *ctx
+	resolved.NATS = configManager.ResolveNATSConfig(ctx)
+
+	return natsClient.NewClientFromContext(&resolved)
 }
 
 // Common flag variables that will be used across NATS commands
@@ -172,7 +170,7 @@ var (
 
 func init() {
 	// Add persistent flags that apply to all NATS commands
-	NATSCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "Output format (text|json|yaml)")
+	NATSCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "Output format (text|json|yaml|ndjson|csv|tsv)")
 	NATSCmd.PersistentFlags().StringVar(&timeout, "timeout", "30s", "Operation timeout")
 	NATSCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
 }
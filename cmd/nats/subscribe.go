@@ -2,6 +2,7 @@ package nats
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"strings"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"flint-cli/internal/config"
 	natsClient "flint-cli/internal/nats"
 	"flint-cli/internal/utils"
 )
@@ -67,6 +69,12 @@ Examples:
   # Subscribe and show message headers and timestamps
   flint nats subscribe "telemetry.edge.edge_123" --headers --timestamp
 
+  # One JSON document per message, for piping into jq (status goes to stderr)
+  flint nats subscribe "telemetry.>" --output ndjson --timestamp | jq .data
+
+  # Record messages as CSV rows
+  flint nats subscribe "events.>" --output csv --timestamp > events.csv
+
 Press Ctrl+C to stop the subscription at any time.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -105,8 +113,20 @@ Press Ctrl+C to stop the subscription at any time.`,
 			}
 		}()
 		
+		// One-record-per-line formats keep stdout for the messages only
+		info := os.Stdout
+		var stream *utils.RecordStream
+		if !subscribeRaw && utils.IsRecordFormat(outputFormat) {
+			stream, err = utils.NewRecordStream(os.Stdout, outputFormat, messageColumns(subscribeHeaders, subscribeTimestamp))
+			if err != nil {
+				return err
+			}
+			info = os.Stderr
+			config.Global.Quiet = true
+		}
+
 		// Display subscription info
-		displaySubscriptionInfo(subject, subscribeQueue, timeoutDuration, subscribeCount)
+		displaySubscriptionInfo(info, subject, subscribeQueue, timeoutDuration, subscribeCount)
 		
 		// Create message handler based on output preferences
 		var messageCount int
@@ -116,6 +136,13 @@ Press Ctrl+C to stop the subscription at any time.`,
 			// Display the message
 			if subscribeRaw {
 				displayRawMessage(msg)
+			} else if stream != nil {
+				if err := stream.Write(messageRecord(msg, subscribeHeaders, subscribeTimestamp)); err != nil {
+					return err
+				}
+				if err := stream.Flush(); err != nil {
+					return err
+				}
 			} else {
 				displayFormattedMessage(msg, outputFormat, subscribeHeaders, subscribeTimestamp)
 			}
@@ -150,14 +177,20 @@ Press Ctrl+C to stop the subscription at any time.`,
 			utils.PrintInfo(fmt.Sprintf("Received signal %v, stopping subscription...", sig))
 		}
 		
+		if stream != nil {
+			if err := stream.Close(); err != nil {
+				return err
+			}
+		}
+		
 		// Show final statistics
 		green := color.New(color.FgGreen).SprintFunc()
-		fmt.Printf("\n%s Subscription completed\n", green("✓"))
-		fmt.Printf("  Subject: %s\n", subject)
+		fmt.Fprintf(info, "\n%s Subscription completed\n", green("✓"))
+		fmt.Fprintf(info, "  Subject: %s\n", subject)
 		if subscribeQueue != "" {
-			fmt.Printf("  Queue: %s\n", subscribeQueue)
+			fmt.Fprintf(info, "  Queue: %s\n", subscribeQueue)
 		}
-		fmt.Printf("  Messages received: %d\n", messageCount)
+		fmt.Fprintf(info, "  Messages received: %d\n", messageCount)
 		
 		return nil
 	},
@@ -177,26 +210,39 @@ func init() {
 }
 
 // displaySubscriptionInfo shows information about the subscription being created
-func displaySubscriptionInfo(subject, queue string, timeout time.Duration, count int) {
+func displaySubscriptionInfo(w io.Writer, subject, queue string, timeout time.Duration, count int) {
 	cyan := color.New(color.FgCyan).SprintFunc()
 	
-	fmt.Printf("Subscribing to NATS:\n")
-	fmt.Printf("  Subject: %s\n", cyan(subject))
+	fmt.Fprintf(w, "Subscribing to NATS:\n")
+	fmt.Fprintf(w, "  Subject: %s\n", cyan(subject))
 	
 	if queue != "" {
-		fmt.Printf("  Queue Group: %s\n", cyan(queue))
+		fmt.Fprintf(w, "  Queue Group: %s\n", cyan(queue))
 	}
 	
 	if timeout > 0 {
-		fmt.Printf("  Timeout: %v\n", timeout)
+		fmt.Fprintf(w, "  Timeout: %v\n", timeout)
 	}
 	
 	if count > 0 {
-		fmt.Printf("  Message Limit: %d\n", count)
+		fmt.Fprintf(w, "  Message Limit: %d\n", count)
 	}
 	
-	fmt.Printf("\nWaiting for messages... (Press Ctrl+C to stop)\n")
-	fmt.Println(strings.Repeat("═", 80))
+	fmt.Fprintf(w, "\nWaiting for messages... (Press Ctrl+C to stop)\n")
+	fmt.Fprintln(w, strings.Repeat("═", 80))
+}
+
+// messageColumns returns the csv/tsv columns for subscribed messages
+func messageColumns(showHeaders, showTimestamp bool) []string {
+	var columns []string
+	if showTimestamp {
+		columns = append(columns, "timestamp")
+	}
+	columns = append(columns, "subject", "reply", "size", "data")
+	if showHeaders {
+		columns = append(columns, "headers")
+	}
+	return columns
 }
 
 // displayRawMessage displays a message in raw format
@@ -262,8 +308,8 @@ func displayTextMessage(msg *natsClient.Message, showHeaders, showTimestamp bool
 	fmt.Println()
 }
 
-// displayJSONMessage displays a message as JSON
-func displayJSONMessage(msg *natsClient.Message, showHeaders, showTimestamp bool) {
+// messageRecord converts a message to the record written by the structured formats
+func messageRecord(msg *natsClient.Message, showHeaders, showTimestamp bool) map[string]interface{} {
 	output := map[string]interface{}{
 		"subject": msg.Subject,
 		"data":    string(msg.Data),
@@ -282,6 +328,13 @@ func displayJSONMessage(msg *natsClient.Message, showHeaders, showTimestamp bool
 		output["headers"] = msg.Headers
 	}
 	
+	return output
+}
+
+// displayJSONMessage displays a message as JSON
+func displayJSONMessage(msg *natsClient.Message, showHeaders, showTimestamp bool) {
+	output := messageRecord(msg, showHeaders, showTimestamp)
+	
 	if err := utils.OutputData(output, "json"); err != nil {
 		utils.PrintError(fmt.Errorf("failed to format message as JSON: %w", err))
 		fmt.Printf("Raw: %s\n", string(msg.Data))
@@ -290,23 +343,7 @@ func displayJSONMessage(msg *natsClient.Message, showHeaders, showTimestamp bool
 
 // displayYAMLMessage displays a message as YAML
 func displayYAMLMessage(msg *natsClient.Message, showHeaders, showTimestamp bool) {
-	output := map[string]interface{}{
-		"subject": msg.Subject,
-		"data":    string(msg.Data),
-		"size":    msg.Size,
-	}
-	
-	if msg.Reply != "" {
-		output["reply"] = msg.Reply
-	}
-	
-	if showTimestamp {
-		output["timestamp"] = msg.Timestamp.Format(time.RFC3339Nano)
-	}
-	
-	if showHeaders && len(msg.Headers) > 0 {
-		output["headers"] = msg.Headers
-	}
+	output := messageRecord(msg, showHeaders, showTimestamp)
 	
 	if err := utils.OutputData(output, "yaml"); err != nil {
 		utils.PrintError(fmt.Errorf("failed to format message as YAML: %w", err))
@@ -12,11 +12,13 @@ import (
 	"flint-cli/cmd/nats"
 	"flint-cli/internal/config"
 	"flint-cli/internal/resolver"
+	"flint-cli/internal/utils"
 )
 
 var (
 	configManager *config.Manager
 	cmdResolver   *resolver.CommandResolver
+	contextFlag   string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -29,6 +31,7 @@ performing CRUD operations on collections, and interacting with NATS messaging.
 
 Features:
 - Multi-environment context management with organization support
+- Per-invocation context selection (--context flag or FLINT_CONTEXT)
 - PocketBase authentication and collection operations
 - NATS messaging with multiple authentication methods
 - Partial command matching (Cisco-style)
@@ -46,6 +49,29 @@ Features:
 			return fmt.Errorf("failed to initialize configuration: %w", err)
 		}
 
+		// Configure where context secrets are stored
+		globalConfig, err := configManager.LoadGlobalConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load global config: %w", err)
+		}
+		if err := configManager.ConfigureSecretStore(globalConfig); err != nil {
+			return fmt.Errorf("invalid secret store configuration: %w", err)
+		}
+
+		// Discover directory-local project settings (.flint.yaml)
+		project, err := configManager.LoadProjectConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load project config: %w", err)
+		}
+
+		// Apply a per-invocation context override without touching config.yaml
+		applyContextOverride()
+
+		// Resolve the default output format (flag > .flint.yaml > config.yaml)
+		if err := applyOutputFormat(cmd, project, globalConfig); err != nil {
+			return err
+		}
+
 		// Initialize command resolver for partial matching
 		cmdResolver = resolver.NewCommandResolver()
 
@@ -70,9 +96,10 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	// Global flags
-	rootCmd.PersistentFlags().StringVar(&config.Global.OutputFormat, "output", "json", "Output format (json|yaml|table)")
+	rootCmd.PersistentFlags().StringVar(&config.Global.OutputFormat, "output", "json", "Output format (json|yaml|table|ndjson|csv|tsv)")
 	rootCmd.PersistentFlags().BoolVar(&config.Global.ColorsEnabled, "colors", true, "Enable colored output")
 	rootCmd.PersistentFlags().BoolVar(&config.Global.Debug, "debug", false, "Enable debug output")
+	rootCmd.PersistentFlags().StringVar(&contextFlag, "context", "", "Context to use for this command (overrides active context and FLINT_CONTEXT)")
 
 	// Bind flags to viper
 	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
@@ -98,6 +125,42 @@ func addCommands() {
 	rootCmd.AddCommand(nats.NATSCmd)
 }
 
+// applyContextOverride selects the context from the --context flag or FLINT_CONTEXT.
+// The context is checked for existence when a command resolves it.
+func applyContextOverride() {
+	name, source := contextFlag, config.ContextSourceFlag
+	if name == "" {
+		name, source = os.Getenv("FLINT_CONTEXT"), config.ContextSourceEnv
+	}
+	if name != "" {
+		configManager.SetContextOverride(name, source)
+	}
+}
+
+// applyOutputFormat sets the default output format when --output was not given
+func applyOutputFormat(cmd *cobra.Command, project *config.ProjectConfig, globalConfig *config.GlobalConfig) error {
+	switch {
+	case cmd.Flags().Changed("output"):
+		configManager.SetSettingSource(config.SettingOutputFormat, config.SettingSourceOutputFlag)
+
+	case project != nil && project.OutputFormat != "":
+		if err := utils.ValidateOutputFormat(project.OutputFormat); err != nil {
+			return fmt.Errorf("invalid output_format in %s: %w", configManager.ProjectSource(), err)
+		}
+		config.Global.OutputFormat = project.OutputFormat
+		configManager.SetSettingSource(config.SettingOutputFormat, configManager.ProjectSource())
+
+	case globalConfig.OutputFormat != "":
+		config.Global.OutputFormat = globalConfig.OutputFormat
+		configManager.SetSettingSource(config.SettingOutputFormat, config.ContextSourceConfig)
+
+	default:
+		configManager.SetSettingSource(config.SettingOutputFormat, config.SettingSourceDefault)
+	}
+
+	return nil
+}
+
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
 	// Set config file type
@@ -0,0 +1,367 @@
+package config
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"flint-cli/internal/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+// Bundle layout constants
+const (
+	bundleVersion      = 1
+	bundleManifestName = "bundle.yaml"
+	bundleContextName  = "context.yaml"
+	maxBundleFileSize  = 10 * 1024 * 1024 // 10MB per file
+)
+
+// BundleManifest describes the contents of a context bundle
+type BundleManifest struct {
+	Version       int       `yaml:"version"`
+	Context       string    `yaml:"context"`
+	ExportedAt    time.Time `yaml:"exported_at"`
+	IncludesToken bool      `yaml:"includes_token"`
+	Files         []string  `yaml:"files"`
+}
+
+// ContextBundle is an exported context with its related files (e.g. nats.creds)
+type ContextBundle struct {
+	Manifest BundleManifest
+	Context  *Context
+	Files    map[string][]byte // file name -> content, relative to the context directory
+}
+
+// ExportOptions controls what goes into a context bundle
+type ExportOptions struct {
+	KeepTokens bool   // Keep the PocketBase session, headers and proxy credentials
+	Passphrase []byte // Encrypt the bundle when set
+}
+
+// ExportContext packages a context and its directory files into a tar.gz bundle
+func (m *Manager) ExportContext(name string, opts ExportOptions) ([]byte, error) {
+	context, err := m.LoadContext(name)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &ContextBundle{
+		Context: context,
+		Files:   make(map[string][]byte),
+	}
+
+	// Strip the PocketBase session and request secrets unless explicitly kept
+	if !opts.KeepTokens {
+		context.PocketBase.AuthToken = ""
+		context.PocketBase.AuthExpires = nil
+		context.PocketBase.AuthRecord = nil
+		context.PocketBase.Headers = nil
+		context.PocketBase.ProxyURL = StripURLCredentials(context.PocketBase.ProxyURL)
+	}
+
+	// Collect regular files from the context directory
+	contextDir := m.GetContextDir(name)
+	entries, err := os.ReadDir(contextDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read context directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !isBundleFile(entry.Name()) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(contextDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s': %w", entry.Name(), err)
+		}
+		bundle.Files[entry.Name()] = data
+	}
+
+	// Pull in files referenced by absolute path (creds, seed, TLS) so the bundle is self-contained
+	for _, path := range append(context.NATS.FilePaths(), context.PocketBase.FilePaths()...) {
+		if *path == "" || !filepath.IsAbs(*path) {
+			continue
+		}
+		data, err := os.ReadFile(*path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read referenced file: %w", err)
+		}
+		base := filepath.Base(*path)
+		bundle.Files[base] = data
+		*path = "./" + base
+	}
+
+	bundle.Manifest = BundleManifest{
+		Version:       bundleVersion,
+		Context:       name,
+		ExportedAt:    time.Now().UTC(),
+		IncludesToken: context.PocketBase.AuthToken != "",
+	}
+	for fileName := range bundle.Files {
+		bundle.Manifest.Files = append(bundle.Manifest.Files, fileName)
+	}
+	sort.Strings(bundle.Manifest.Files)
+
+	data, err := writeBundleArchive(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.Passphrase) > 0 {
+		return secrets.Encrypt(data, opts.Passphrase)
+	}
+
+	return data, nil
+}
+
+// IsEncryptedBundle reports whether bundle data is encrypted
+func IsEncryptedBundle(data []byte) bool {
+	return !isGzip(data) && secrets.IsEncrypted(data)
+}
+
+// ReadContextBundle parses bundle data, decrypting it with passphrase if needed
+func ReadContextBundle(data, passphrase []byte) (*ContextBundle, error) {
+	if IsEncryptedBundle(data) {
+		if len(passphrase) == 0 {
+			return nil, fmt.Errorf("bundle is encrypted, a passphrase is required")
+		}
+		decrypted, err := secrets.Decrypt(data, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt bundle: %w", err)
+		}
+		data = decrypted
+	}
+
+	if !isGzip(data) {
+		return nil, fmt.Errorf("not a flint context bundle")
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer gz.Close()
+
+	bundle := &ContextBundle{Files: make(map[string][]byte)}
+	var haveManifest bool
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if !isSafeBundleName(header.Name) {
+			return nil, fmt.Errorf("bundle contains invalid file name '%s'", header.Name)
+		}
+		if header.Size > maxBundleFileSize {
+			return nil, fmt.Errorf("bundle file '%s' is too large", header.Name)
+		}
+
+		content, err := io.ReadAll(io.LimitReader(tr, maxBundleFileSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s' from bundle: %w", header.Name, err)
+		}
+
+		switch header.Name {
+		case bundleManifestName:
+			if err := yaml.Unmarshal(content, &bundle.Manifest); err != nil {
+				return nil, fmt.Errorf("invalid bundle manifest: %w", err)
+			}
+			haveManifest = true
+		case bundleContextName:
+			var context Context
+			if err := yaml.Unmarshal(content, &context); err != nil {
+				return nil, fmt.Errorf("invalid context in bundle: %w", err)
+			}
+			bundle.Context = &context
+		default:
+			bundle.Files[header.Name] = content
+		}
+	}
+
+	if !haveManifest || bundle.Context == nil {
+		return nil, fmt.Errorf("not a flint context bundle (missing %s or %s)", bundleManifestName, bundleContextName)
+	}
+	if bundle.Manifest.Version > bundleVersion {
+		return nil, fmt.Errorf("bundle version %d is not supported by this version of flint", bundle.Manifest.Version)
+	}
+
+	return bundle, nil
+}
+
+// InstallContextBundle writes a bundle as a new context called name. The
+// context is staged in a temporary directory and moved into place, so an
+// existing context is only replaced once the new one is complete.
+func (m *Manager) InstallContextBundle(bundle *ContextBundle, name string, overwrite bool) error {
+	exists := m.ContextExists(name)
+	if exists && !overwrite {
+		return fmt.Errorf("context '%s' already exists", name)
+	}
+
+	bundle.Context.Name = name
+
+	tmpDir, err := os.MkdirTemp(m.configDir, "."+name+"-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir) // No-op once renamed into place
+
+	for fileName, content := range bundle.Files {
+		if err := os.WriteFile(filepath.Join(tmpDir, fileName), content, 0600); err != nil {
+			return fmt.Errorf("failed to write '%s': %w", fileName, err)
+		}
+	}
+
+	// Write the context file, keeping secrets aside when a secret store is configured
+	toWrite := *bundle.Context
+	var values map[string]string
+	if m.secretStore != nil {
+		values, err = extractSecrets(&toWrite)
+		if err != nil {
+			return fmt.Errorf("failed to extract secrets: %w", err)
+		}
+	}
+	if err := writeContextFile(filepath.Join(tmpDir, bundleContextName), &toWrite); err != nil {
+		return err
+	}
+
+	// Move the existing context aside under its lock, and bring it back if
+	// the new one cannot be put in place
+	contextDir := m.GetContextDir(name)
+	var oldDir string
+	if exists {
+		unlock, err := m.lockContext(name)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		oldDir = tmpDir + ".old"
+		if err := os.Rename(contextDir, oldDir); err != nil {
+			return fmt.Errorf("failed to replace existing context: %w", err)
+		}
+		defer os.RemoveAll(oldDir)
+	}
+	restore := func() {
+		if oldDir != "" {
+			os.RemoveAll(contextDir)
+			os.Rename(oldDir, contextDir)
+		}
+	}
+
+	if err := os.Rename(tmpDir, contextDir); err != nil {
+		restore()
+		return fmt.Errorf("failed to create context directory: %w", err)
+	}
+
+	if m.secretStore != nil {
+		if err := m.secretStore.Save(name, values); err != nil {
+			if oldDir == "" {
+				os.RemoveAll(contextDir)
+			}
+			restore()
+			return fmt.Errorf("failed to save secrets: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// writeBundleArchive serializes a bundle into a tar.gz archive
+func writeBundleArchive(bundle *ContextBundle) ([]byte, error) {
+	manifest, err := yaml.Marshal(bundle.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle manifest: %w", err)
+	}
+	context, err := yaml.Marshal(bundle.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal context: %w", err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{bundleManifestName, manifest},
+		{bundleContextName, context},
+	}
+	for _, fileName := range bundle.Manifest.Files {
+		files = append(files, struct {
+			name string
+			data []byte
+		}{fileName, bundle.Files[fileName]})
+	}
+
+	for _, file := range files {
+		header := &tar.Header{
+			Name:    file.name,
+			Mode:    0600,
+			Size:    int64(len(file.data)),
+			ModTime: bundle.Manifest.ExportedAt,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, fmt.Errorf("failed to write bundle: %w", err)
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return nil, fmt.Errorf("failed to write bundle: %w", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// isBundleFile reports whether a context directory file belongs in an export
+func isBundleFile(name string) bool {
+	switch {
+	case name == bundleContextName, name == secrets.VaultFileName:
+		return false
+	case strings.HasPrefix(name, "."), strings.HasSuffix(name, ".tmp"), strings.HasSuffix(name, ".lock"):
+		return false
+	}
+	return true
+}
+
+// isSafeBundleName rejects paths that could escape the context directory, and
+// the files flint manages there (vault, lock and temporary files) that
+// isBundleFile leaves out of an export
+func isSafeBundleName(name string) bool {
+	if name == bundleManifestName || name == bundleContextName {
+		return true
+	}
+	return name != "" &&
+		name == filepath.Base(name) &&
+		!strings.ContainsAny(name, `/\`) &&
+		isBundleFile(name)
+}
+
+// isGzip reports whether data starts with the gzip magic bytes
+func isGzip(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+	"time"
+
+	"flint-cli/internal/secrets"
+)
+
+// bundleEntry is a file written into a test archive
+type bundleEntry struct {
+	name string
+	data string
+}
+
+// buildArchive writes entries into a tar.gz archive as-is, without the checks
+// of writeBundleArchive
+func buildArchive(t *testing.T, entries ...bundleEntry) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0600, Size: int64(len(entry.data))}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("WriteHeader: %v", err)
+		}
+		if _, err := tw.Write([]byte(entry.data)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestIsSafeBundleName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{bundleManifestName, true},
+		{bundleContextName, true},
+		{"nats.creds", true},
+		{"ca.pem", true},
+		{"", false},
+		{"../nats.creds", false},
+		{"sub/nats.creds", false},
+		{"/etc/passwd", false},
+		{`..\nats.creds`, false},
+		{`sub\nats.creds`, false},
+		{secrets.VaultFileName, false},
+		{".hidden", false},
+		{"context.lock", false},
+		{"nats.creds.tmp", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSafeBundleName(tt.name); got != tt.want {
+				t.Errorf("isSafeBundleName(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadContextBundle(t *testing.T) {
+	bundle := &ContextBundle{
+		Manifest: BundleManifest{
+			Version:    bundleVersion,
+			Context:    "doc",
+			ExportedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Files:      []string{"nats.creds"},
+		},
+		Context: &Context{Name: "doc", PocketBase: PocketBaseConfig{URL: "https://pb.example.com"}},
+		Files:   map[string][]byte{"nats.creds": []byte("-----BEGIN NATS USER JWT-----")},
+	}
+	archive, err := writeBundleArchive(bundle)
+	if err != nil {
+		t.Fatalf("writeBundleArchive: %v", err)
+	}
+	encrypted, err := secrets.Encrypt(archive, []byte("passphrase"))
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	manifest := bundleEntry{bundleManifestName, "version: 1\ncontext: doc\n"}
+	context := bundleEntry{bundleContextName, "name: doc\n"}
+
+	tests := []struct {
+		name       string
+		data       []byte
+		passphrase string
+		wantErr    string
+	}{
+		{name: "plain", data: archive},
+		{name: "encrypted", data: encrypted, passphrase: "passphrase"},
+		{name: "encrypted without passphrase", data: encrypted, wantErr: "passphrase is required"},
+		{name: "wrong passphrase", data: encrypted, passphrase: "other", wantErr: "failed to decrypt"},
+		{name: "not a bundle", data: []byte("name: doc\n"), wantErr: "not a flint context bundle"},
+		{name: "missing manifest", data: buildArchive(t, context), wantErr: "missing"},
+		{name: "missing context", data: buildArchive(t, manifest), wantErr: "missing"},
+		{name: "newer version", data: buildArchive(t, bundleEntry{bundleManifestName, "version: 99\n"}, context), wantErr: "not supported"},
+		{name: "path traversal", data: buildArchive(t, manifest, context, bundleEntry{"../../.bashrc", "x"}), wantErr: "invalid file name"},
+		{name: "absolute path", data: buildArchive(t, manifest, context, bundleEntry{"/tmp/nats.creds", "x"}), wantErr: "invalid file name"},
+		{name: "vault file", data: buildArchive(t, manifest, context, bundleEntry{secrets.VaultFileName, "x"}), wantErr: "invalid file name"},
+		{name: "invalid manifest", data: buildArchive(t, bundleEntry{bundleManifestName, "version: [\n"}, context), wantErr: "invalid bundle manifest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadContextBundle(tt.data, []byte(tt.passphrase))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReadContextBundle error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadContextBundle: %v", err)
+			}
+
+			if got.Context.Name != "doc" || got.Context.PocketBase.URL != "https://pb.example.com" {
+				t.Errorf("Context = %+v", got.Context)
+			}
+			if got.Manifest.Context != "doc" || len(got.Manifest.Files) != 1 {
+				t.Errorf("Manifest = %+v", got.Manifest)
+			}
+			if string(got.Files["nats.creds"]) != "-----BEGIN NATS USER JWT-----" {
+				t.Errorf("Files = %q", got.Files)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// CloneContext creates dst as a copy of src, including credentials files.
+// modify (optional) is applied to the copy before it is written.
+// The new context directory appears atomically: it is assembled in a hidden
+// temporary directory and renamed into place.
+func (m *Manager) CloneContext(src, dst string, modify func(*Context)) (*Context, error) {
+	if m.ContextExists(dst) {
+		return nil, fmt.Errorf("context '%s' already exists", dst)
+	}
+
+	context, err := m.LoadContext(src)
+	if err != nil {
+		return nil, err
+	}
+
+	context.Name = dst
+	if modify != nil {
+		modify(context)
+	}
+
+	tmpDir, err := os.MkdirTemp(m.configDir, "."+dst+"-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir) // No-op once renamed into place
+
+	// Copy related files (creds etc.); context.yaml and the vault are rewritten below,
+	// lock and temporary files are skipped
+	srcDir := m.GetContextDir(src)
+	entries, err := os.ReadDir(srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read context directory: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || !isBundleFile(name) {
+			continue
+		}
+		if err := copyFile(filepath.Join(srcDir, name), filepath.Join(tmpDir, name)); err != nil {
+			return nil, fmt.Errorf("failed to copy '%s': %w", name, err)
+		}
+	}
+
+	// Write the context file, keeping secrets aside when a secret store is configured
+	toWrite := *context
+	var values map[string]string
+	if m.secretStore != nil {
+		values, err = extractSecrets(&toWrite)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract secrets: %w", err)
+		}
+	}
+	if err := writeContextFile(filepath.Join(tmpDir, "context.yaml"), &toWrite); err != nil {
+		return nil, err
+	}
+
+	dstDir := m.GetContextDir(dst)
+	if err := os.Rename(tmpDir, dstDir); err != nil {
+		return nil, fmt.Errorf("failed to create context directory: %w", err)
+	}
+
+	if m.secretStore != nil {
+		if err := m.secretStore.Save(dst, values); err != nil {
+			os.RemoveAll(dstDir)
+			return nil, fmt.Errorf("failed to save secrets: %w", err)
+		}
+	}
+
+	return context, nil
+}
+
+// RenameContext renames a context, moving its directory and updating active_context
+func (m *Manager) RenameContext(oldName, newName string) error {
+	if !m.ContextExists(oldName) {
+		return fmt.Errorf("context '%s' not found", oldName)
+	}
+	if m.ContextExists(newName) {
+		return fmt.Errorf("context '%s' already exists", newName)
+	}
+
+	// Read without the secret store - secrets stay where they are and move with the directory
+	context, err := m.loadContextFile(oldName)
+	if err != nil {
+		return err
+	}
+
+	oldDir := m.GetContextDir(oldName)
+	newDir := m.GetContextDir(newName)
+	if err := os.Rename(oldDir, newDir); err != nil {
+		return fmt.Errorf("failed to move context directory: %w", err)
+	}
+
+	context.Name = newName
+	if err := writeContextFile(m.GetContextPath(newName), context); err != nil {
+		// Roll back the move so the context stays usable under its old name
+		os.Rename(newDir, oldDir)
+		return err
+	}
+
+	globalConfig, err := m.LoadGlobalConfig()
+	if err != nil {
+		return fmt.Errorf("context renamed but failed to load global config: %w", err)
+	}
+	if globalConfig.ActiveContext == oldName {
+		globalConfig.ActiveContext = newName
+		if err := m.SaveGlobalConfig(globalConfig); err != nil {
+			return fmt.Errorf("context renamed but failed to update active context: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// copyFile copies a regular file, preserving its permissions
+func copyFile(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Context lock settings
+const (
+	contextLockName    = "context.lock"
+	contextLockTimeout = 40 * time.Second // Longer than a PocketBase request
+	contextLockStale   = 2 * time.Minute  // Locks older than this were left by a crashed process
+	contextLockPoll    = 50 * time.Millisecond
+)
+
+// lockContext takes an exclusive lock on a context directory so concurrent
+// flint processes never interleave writes to context.yaml or the vault.
+// The returned function releases the lock.
+func (m *Manager) lockContext(name string) (func(), error) {
+	lockPath := filepath.Join(m.GetContextDir(name), contextLockName)
+	deadline := time.Now().Add(contextLockTimeout)
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock context '%s': %w", name, err)
+		}
+
+		// Break locks left behind by a process that died while holding them
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > contextLockStale {
+			os.Remove(lockPath)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			owner := "another flint process"
+			if data, readErr := os.ReadFile(lockPath); readErr == nil {
+				if pid, convErr := strconv.Atoi(strings.TrimSpace(string(data))); convErr == nil {
+					owner = fmt.Sprintf("process %d", pid)
+				}
+			}
+			return nil, fmt.Errorf("context '%s' is locked by %s. Remove %s if that process is no longer running",
+				name, owner, lockPath)
+		}
+
+		time.Sleep(contextLockPoll)
+	}
+}
+
+// UpdateContext applies fn to the stored context under the context lock and
+// saves the result. The context is re-read after the lock is taken, so fn
+// always sees changes made by other processes. Nothing is written if fn fails.
+func (m *Manager) UpdateContext(name string, fn func(*Context) error) (*Context, error) {
+	if !m.ContextExists(name) {
+		return nil, fmt.Errorf("context '%s' not found", name)
+	}
+
+	unlock, err := m.lockContext(name)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	context, err := m.LoadContext(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fn(context); err != nil {
+		return nil, err
+	}
+
+	if err := m.saveContext(context); err != nil {
+		return nil, err
+	}
+
+	return context, nil
+}
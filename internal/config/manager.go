@@ -1,19 +1,55 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/adrg/xdg"
 	"gopkg.in/yaml.v3"
 )
 
+// ContextNotFoundError reports a selected context that does not exist
+type ContextNotFoundError struct {
+	Name      string
+	Source    string
+	Available []string
+}
+
+func (e *ContextNotFoundError) Error() string {
+	if len(e.Available) > 0 {
+		return fmt.Sprintf("context '%s' (from %s) not found. Available contexts: %v", e.Name, e.Source, e.Available)
+	}
+	return fmt.Sprintf("context '%s' (from %s) not found", e.Name, e.Source)
+}
+
+// IsContextNotFound reports whether err is a ContextNotFoundError
+func IsContextNotFound(err error) bool {
+	var notFound *ContextNotFoundError
+	return errors.As(err, &notFound)
+}
+
 // Manager handles configuration and context management
 type Manager struct {
-	configDir string
+	configDir       string
+	secretStore     SecretStore
+	contextOverride string
+	overrideSource  string
+	project         *ProjectConfig
+	projectPath     string
+	orgOverrides    map[string]string // context name -> organization ID stored in context.yaml
+	settingSources  map[string]string
 }
 
+// Active context source constants
+const (
+	ContextSourceFlag   = "--context flag"
+	ContextSourceEnv    = "FLINT_CONTEXT"
+	ContextSourceConfig = "config.yaml"
+)
+
 // NewManager creates a new configuration manager
 func NewManager() (*Manager, error) {
 	// Create XDG-compliant config directory
@@ -99,8 +135,40 @@ func (m *Manager) SaveGlobalConfig(config *GlobalConfig) error {
 	return nil
 }
 
+// SetSecretStore configures where sensitive context fields are kept.
+// A nil store keeps secrets in plaintext inside context.yaml.
+func (m *Manager) SetSecretStore(store SecretStore) {
+	m.secretStore = store
+}
+
+// GetSecretStore returns the configured secret store (nil for plaintext)
+func (m *Manager) GetSecretStore() SecretStore {
+	return m.secretStore
+}
+
 // LoadContext loads a specific context configuration
 func (m *Manager) LoadContext(name string) (*Context, error) {
+	context, err := m.loadContextFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch sensitive fields from the secret store
+	if m.secretStore != nil {
+		secrets, err := m.secretStore.Load(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load secrets for context '%s': %w", name, err)
+		}
+		if err := applySecrets(context, secrets); err != nil {
+			return nil, fmt.Errorf("failed to apply secrets for context '%s': %w", name, err)
+		}
+	}
+
+	return context, nil
+}
+
+// loadContextFile reads and parses context.yaml without consulting the secret store
+func (m *Manager) loadContextFile(name string) (*Context, error) {
 	if name == "" {
 		return nil, fmt.Errorf("context name cannot be empty")
 	}
@@ -136,15 +204,65 @@ func (m *Manager) SaveContext(context *Context) error {
 		return fmt.Errorf("failed to create context directory: %w", err)
 	}
 
+	unlock, err := m.lockContext(context.Name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return m.saveContext(context)
+}
+
+// saveContext writes a context; the caller must hold the context lock
+func (m *Manager) saveContext(context *Context) error {
+	toWrite := *context
+
+	// Don't persist an organization that only came from .flint.yaml
+	if original, ok := m.orgOverrides[context.Name]; ok &&
+		toWrite.PocketBase.OrganizationID == m.project.OrganizationID {
+		toWrite.PocketBase.OrganizationID = original
+	}
+
+	// Keep sensitive fields out of the YAML when a secret store is configured
+	if m.secretStore != nil {
+		secrets, err := extractSecrets(&toWrite)
+		if err != nil {
+			return fmt.Errorf("failed to extract secrets: %w", err)
+		}
+		if err := m.secretStore.Save(context.Name, secrets); err != nil {
+			return fmt.Errorf("failed to save secrets: %w", err)
+		}
+	}
+
 	// Save context configuration
-	contextPath := m.GetContextPath(context.Name)
-	
+	return writeContextFile(m.GetContextPath(context.Name), &toWrite)
+}
+
+// writeContextFile marshals a context and atomically writes it to path as-is
+func writeContextFile(path string, context *Context) error {
 	data, err := yaml.Marshal(context)
 	if err != nil {
 		return fmt.Errorf("failed to marshal context: %w", err)
 	}
 
-	if err := os.WriteFile(contextPath, data, 0644); err != nil {
+	// Write to a temporary file first so readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".context-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write context file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed into place
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write context file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write context file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write context file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
 		return fmt.Errorf("failed to write context file: %w", err)
 	}
 
@@ -161,8 +279,8 @@ func (m *Manager) ListContexts() ([]string, error) {
 
 	var contexts []string
 	for _, entry := range entries {
-		// Skip files and check only directories
-		if !entry.IsDir() {
+		// Skip files and hidden directories (e.g. in-progress clones)
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
 			continue
 		}
 
@@ -196,18 +314,57 @@ func (m *Manager) DeleteContext(name string) error {
 	return nil
 }
 
+// SetContextOverride overrides the active context for this invocation only.
+// source describes where the override came from (e.g. ContextSourceFlag).
+func (m *Manager) SetContextOverride(name, source string) {
+	m.contextOverride = name
+	m.overrideSource = source
+}
+
+// GetActiveContextName returns the effective active context name and where it was set
+func (m *Manager) GetActiveContextName() (string, string, error) {
+	if m.contextOverride != "" {
+		return m.contextOverride, m.overrideSource, nil
+	}
+
+	if m.project != nil && m.project.Context != "" {
+		return m.project.Context, m.ProjectSource(), nil
+	}
+
+	globalConfig, err := m.LoadGlobalConfig()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to load global config: %w", err)
+	}
+
+	return globalConfig.ActiveContext, ContextSourceConfig, nil
+}
+
 // GetActiveContext returns the currently active context
 func (m *Manager) GetActiveContext() (*Context, error) {
-	globalConfig, err := m.LoadGlobalConfig()
+	name, source, err := m.GetActiveContextName()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load global config: %w", err)
+		return nil, err
 	}
 
-	if globalConfig.ActiveContext == "" {
+	if name == "" {
 		return nil, fmt.Errorf("no active context set")
 	}
 
-	return m.LoadContext(globalConfig.ActiveContext)
+	// A context selected for this invocation is only checked once it is used,
+	// so commands that manage contexts keep working with a stale selection
+	if source != ContextSourceConfig && !m.ContextExists(name) {
+		contexts, _ := m.ListContexts()
+		return nil, &ContextNotFoundError{Name: name, Source: source, Available: contexts}
+	}
+
+	context, err := m.LoadContext(name)
+	if err != nil {
+		return nil, err
+	}
+
+	m.applyProjectOverrides(context)
+
+	return context, nil
 }
 
 // SetActiveContext sets the active context
@@ -232,6 +389,37 @@ func (m *Manager) GetContextCredsPath(name string) string {
 	return filepath.Join(m.GetContextDir(name), "nats.creds")
 }
 
+// GetContextNKeyPath returns the path to the NATS NKey seed file for a context
+func (m *Manager) GetContextNKeyPath(name string) string {
+	return filepath.Join(m.GetContextDir(name), "nats.nk")
+}
+
+// ResolveContextPath resolves a path stored in a context ("./" is relative to the context directory)
+func (m *Manager) ResolveContextPath(contextName, path string) string {
+	if strings.HasPrefix(path, "./") {
+		return filepath.Join(m.GetContextDir(contextName), path[2:])
+	}
+	return path
+}
+
+// ResolveNATSConfig returns a copy of a context's NATS configuration with its file paths resolved
+func (m *Manager) ResolveNATSConfig(ctx *Context) NATSConfig {
+	resolved := ctx.NATS
+	for _, path := range resolved.FilePaths() {
+		*path = m.ResolveContextPath(ctx.Name, *path)
+	}
+	return resolved
+}
+
+// ResolvePocketBaseConfig returns a copy of a context's PocketBase configuration with its file paths resolved
+func (m *Manager) ResolvePocketBaseConfig(ctx *Context) PocketBaseConfig {
+	resolved := ctx.PocketBase
+	for _, path := range resolved.FilePaths() {
+		*path = m.ResolveContextPath(ctx.Name, *path)
+	}
+	return resolved
+}
+
 // ContextExists checks if a context exists
 func (m *Manager) ContextExists(name string) bool {
 	contextPath := m.GetContextPath(name)
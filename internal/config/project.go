@@ -0,0 +1,178 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigFileName is the name of the directory-local project configuration file
+const ProjectConfigFileName = ".flint.yaml"
+
+// Setting names used for source reporting
+const (
+	SettingContext      = "context"
+	SettingOrganization = "organization_id"
+	SettingOutputFormat = "output_format"
+	SettingCollections  = "collections"
+)
+
+// Setting source constants (in addition to the ContextSource* values)
+const (
+	SettingSourceProject     = ".flint.yaml"
+	SettingSourceContextFile = "context.yaml"
+	SettingSourceOutputFlag  = "--output flag"
+	SettingSourceDefault     = "default"
+)
+
+// ProjectConfig represents a directory-local .flint.yaml file
+type ProjectConfig struct {
+	Context        string                        `yaml:"context"`         // Context pinned for this project
+	OrganizationID string                        `yaml:"organization_id"` // Organization override
+	OutputFormat   string                        `yaml:"output_format"`   // json|yaml|table
+	Collections    map[string]CollectionDefaults `yaml:"collections"`     // Per-collection defaults ("*" applies to all)
+}
+
+// CollectionDefaults holds default flag values for collection commands
+type CollectionDefaults struct {
+	Limit  int      `yaml:"limit,omitempty"`
+	Sort   string   `yaml:"sort,omitempty"`
+	Filter string   `yaml:"filter,omitempty"`
+	Fields []string `yaml:"fields,omitempty"`
+	Expand []string `yaml:"expand,omitempty"`
+}
+
+// DefaultsFor returns the collection defaults for a collection, merged over the "*" entry
+func (p *ProjectConfig) DefaultsFor(collection string) CollectionDefaults {
+	if p == nil {
+		return CollectionDefaults{}
+	}
+
+	defaults := p.Collections["*"]
+	specific, ok := p.Collections[collection]
+	if !ok {
+		return defaults
+	}
+
+	if specific.Limit > 0 {
+		defaults.Limit = specific.Limit
+	}
+	if specific.Sort != "" {
+		defaults.Sort = specific.Sort
+	}
+	if specific.Filter != "" {
+		defaults.Filter = specific.Filter
+	}
+	if len(specific.Fields) > 0 {
+		defaults.Fields = specific.Fields
+	}
+	if len(specific.Expand) > 0 {
+		defaults.Expand = specific.Expand
+	}
+
+	return defaults
+}
+
+// FindProjectConfig walks up from dir looking for a .flint.yaml file.
+// Returns an empty path if none is found.
+func FindProjectConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve directory: %w", err)
+	}
+
+	for {
+		candidate := filepath.Join(dir, ProjectConfigFileName)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// LoadProjectConfig discovers and loads .flint.yaml from the working directory upwards.
+// Returns nil if no project file exists.
+func (m *Manager) LoadProjectConfig() (*ProjectConfig, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	path, err := FindProjectConfig(wd)
+	if err != nil || path == "" {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var project ProjectConfig
+	if err := yaml.Unmarshal(data, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	m.project = &project
+	m.projectPath = path
+
+	return &project, nil
+}
+
+// GetProjectConfig returns the loaded project configuration and its path (nil if none)
+func (m *Manager) GetProjectConfig() (*ProjectConfig, string) {
+	return m.project, m.projectPath
+}
+
+// SetSettingSource records where the effective value of a setting came from
+func (m *Manager) SetSettingSource(setting, source string) {
+	if m.settingSources == nil {
+		m.settingSources = make(map[string]string)
+	}
+	m.settingSources[setting] = source
+}
+
+// GetSettingSource returns where the effective value of a setting came from
+func (m *Manager) GetSettingSource(setting string) string {
+	return m.settingSources[setting]
+}
+
+// ProjectSource describes the loaded project file for source reporting
+func (m *Manager) ProjectSource() string {
+	return fmt.Sprintf("%s (%s)", SettingSourceProject, m.projectPath)
+}
+
+// applyProjectOverrides applies .flint.yaml values to the active context.
+// The organization is only overridden when the project pins this context (or none).
+func (m *Manager) applyProjectOverrides(ctx *Context) {
+	if m.project == nil || m.project.OrganizationID == "" {
+		return
+	}
+	if m.project.Context != "" && m.project.Context != ctx.Name {
+		return
+	}
+
+	if m.orgOverrides == nil {
+		m.orgOverrides = make(map[string]string)
+	}
+	if _, ok := m.orgOverrides[ctx.Name]; !ok {
+		m.orgOverrides[ctx.Name] = ctx.PocketBase.OrganizationID
+	}
+
+	ctx.PocketBase.OrganizationID = m.project.OrganizationID
+}
+
+// OrganizationSource returns where a context's effective organization ID came from
+func (m *Manager) OrganizationSource(ctx *Context) string {
+	if _, ok := m.orgOverrides[ctx.Name]; ok && ctx.PocketBase.OrganizationID == m.project.OrganizationID {
+		return m.ProjectSource()
+	}
+	return SettingSourceContextFile
+}
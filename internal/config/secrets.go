@@ -0,0 +1,184 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"flint-cli/internal/secrets"
+)
+
+// SecretStore persists sensitive context fields outside of context.yaml
+type SecretStore interface {
+	// Name returns the identifier of the store (e.g. "vault")
+	Name() string
+	// Load returns the stored secrets for a context (empty map if none)
+	Load(contextName string) (map[string]string, error)
+	// Save replaces the stored secrets for a context
+	Save(contextName string, secrets map[string]string) error
+	// Delete removes all stored secrets for a context
+	Delete(contextName string) error
+}
+
+// Secret store type constants
+const (
+	SecretStorePlaintext = "plaintext"
+	SecretStoreVault     = "vault"
+)
+
+// Secret keys for sensitive context fields
+const (
+	SecretPBAuthToken  = "pocketbase.auth_token"
+	SecretPBAuthRecord = "pocketbase.auth_record"
+	SecretPBHeaders    = "pocketbase.headers"   // Static headers, often gateway keys
+	SecretPBProxyURL   = "pocketbase.proxy_url" // Only stored when it carries credentials
+	SecretNATSPassword = "nats.password"
+	SecretNATSToken    = "nats.token"
+)
+
+// ValidateSecretStore validates a secret store type
+func ValidateSecretStore(store string) error {
+	switch store {
+	case "", SecretStorePlaintext, SecretStoreVault:
+		return nil
+	}
+	return fmt.Errorf("invalid secret store '%s'. Valid options: %s, %s",
+		store, SecretStorePlaintext, SecretStoreVault)
+}
+
+// ConfigureSecretStore sets up the secret store selected in the global configuration
+func (m *Manager) ConfigureSecretStore(global *GlobalConfig) error {
+	if err := ValidateSecretStore(global.SecretStore); err != nil {
+		return err
+	}
+
+	switch global.SecretStore {
+	case SecretStoreVault:
+		m.SetSecretStore(secrets.NewVaultStore(m.GetContextDir, secrets.DefaultKeyProvider(global.VaultKeyFile)))
+	default:
+		m.SetSecretStore(nil)
+	}
+
+	return nil
+}
+
+// SecretStoreName returns the name of the active secret store
+func (m *Manager) SecretStoreName() string {
+	if m.secretStore == nil {
+		return SecretStorePlaintext
+	}
+	return m.secretStore.Name()
+}
+
+// extractSecrets moves sensitive fields out of a context into a map.
+// The sensitive fields on the given context are cleared.
+func extractSecrets(ctx *Context) (map[string]string, error) {
+	values := make(map[string]string)
+
+	if ctx.PocketBase.AuthToken != "" {
+		values[SecretPBAuthToken] = ctx.PocketBase.AuthToken
+		ctx.PocketBase.AuthToken = ""
+	}
+
+	if len(ctx.PocketBase.AuthRecord) > 0 {
+		record, err := json.Marshal(ctx.PocketBase.AuthRecord)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode auth record: %w", err)
+		}
+		values[SecretPBAuthRecord] = string(record)
+		ctx.PocketBase.AuthRecord = nil
+	}
+
+	if len(ctx.PocketBase.Headers) > 0 {
+		headers, err := json.Marshal(ctx.PocketBase.Headers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode headers: %w", err)
+		}
+		values[SecretPBHeaders] = string(headers)
+		ctx.PocketBase.Headers = nil
+	}
+
+	if HasURLCredentials(ctx.PocketBase.ProxyURL) {
+		values[SecretPBProxyURL] = ctx.PocketBase.ProxyURL
+		ctx.PocketBase.ProxyURL = ""
+	}
+
+	if ctx.NATS.Password != "" {
+		values[SecretNATSPassword] = ctx.NATS.Password
+		ctx.NATS.Password = ""
+	}
+
+	if ctx.NATS.Token != "" {
+		values[SecretNATSToken] = ctx.NATS.Token
+		ctx.NATS.Token = ""
+	}
+
+	return values, nil
+}
+
+// applySecrets restores sensitive fields from a map into a context.
+// Values already present in the context (e.g. unmigrated plaintext) are kept.
+func applySecrets(ctx *Context, values map[string]string) error {
+	if token, ok := values[SecretPBAuthToken]; ok && ctx.PocketBase.AuthToken == "" {
+		ctx.PocketBase.AuthToken = token
+	}
+
+	if record, ok := values[SecretPBAuthRecord]; ok && len(ctx.PocketBase.AuthRecord) == 0 {
+		var authRecord map[string]interface{}
+		if err := json.Unmarshal([]byte(record), &authRecord); err != nil {
+			return fmt.Errorf("failed to decode auth record: %w", err)
+		}
+		ctx.PocketBase.AuthRecord = authRecord
+	}
+
+	if headers, ok := values[SecretPBHeaders]; ok && len(ctx.PocketBase.Headers) == 0 {
+		if err := json.Unmarshal([]byte(headers), &ctx.PocketBase.Headers); err != nil {
+			return fmt.Errorf("failed to decode headers: %w", err)
+		}
+	}
+
+	if proxyURL, ok := values[SecretPBProxyURL]; ok && ctx.PocketBase.ProxyURL == "" {
+		ctx.PocketBase.ProxyURL = proxyURL
+	}
+
+	if password, ok := values[SecretNATSPassword]; ok && ctx.NATS.Password == "" {
+		ctx.NATS.Password = password
+	}
+
+	if token, ok := values[SecretNATSToken]; ok && ctx.NATS.Token == "" {
+		ctx.NATS.Token = token
+	}
+
+	return nil
+}
+
+// HasPlaintextSecrets reports whether a context file on disk still holds sensitive values
+func (m *Manager) HasPlaintextSecrets(name string) (bool, error) {
+	ctx, err := m.loadContextFile(name)
+	if err != nil {
+		return false, err
+	}
+
+	return ctx.PocketBase.AuthToken != "" ||
+		len(ctx.PocketBase.AuthRecord) > 0 ||
+		len(ctx.PocketBase.Headers) > 0 ||
+		HasURLCredentials(ctx.PocketBase.ProxyURL) ||
+		ctx.NATS.Password != "" ||
+		ctx.NATS.Token != "", nil
+}
+
+// HasURLCredentials reports whether a URL carries a user name or password
+func HasURLCredentials(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	return err == nil && parsed.User != nil
+}
+
+// StripURLCredentials removes the user name and password from a URL
+func StripURLCredentials(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.User == nil {
+		return rawURL
+	}
+	parsed.User = nil
+	return parsed.String()
+}
@@ -14,6 +14,9 @@ type GlobalConfig struct {
 	PaginationSize      int    `yaml:"pagination_size"`
 	OrganizationDisplay bool   `yaml:"organization_display"`
 	Debug               bool   `yaml:"debug"`
+	SecretStore         string `yaml:"secret_store,omitempty"`   // plaintext|vault
+	VaultKeyFile        string `yaml:"vault_key_file,omitempty"` // Key file used instead of a passphrase
+	Quiet               bool   `yaml:"-"`                        // Suppress info/success messages (machine-readable output)
 }
 
 // Context represents a single environment context configuration
@@ -26,24 +29,64 @@ type Context struct {
 // PocketBaseConfig contains PocketBase-specific configuration
 type PocketBaseConfig struct {
 	URL                    string                 `yaml:"url"`
-	AuthCollection         string                 `yaml:"auth_collection"`         // users|clients|edges|things|service_users
+	AuthCollection         string                 `yaml:"auth_collection"`         // users|clients|edges|things|service_users|_superusers
 	OrganizationID         string                 `yaml:"organization_id"`         // Set after authentication
 	AvailableCollections   []string               `yaml:"available_collections"`
 	AuthToken              string                 `yaml:"auth_token"`              // Session token
 	AuthExpires            *time.Time             `yaml:"auth_expires"`            // Token expiration
 	AuthRecord             map[string]interface{} `yaml:"auth_record"`             // Cached auth record
+	ImpersonatedBy         string                 `yaml:"impersonated_by,omitempty"` // Superuser context that minted the token
+	CredentialHelper       *CredentialHelper      `yaml:"credential_helper,omitempty"` // External command supplying the session token
+
+	// HTTP transport settings
+	TLSCAFile              string                 `yaml:"tls_ca_file,omitempty"`              // PEM bundle of extra CAs trusted for the server
+	TLSCertFile            string                 `yaml:"tls_cert_file,omitempty"`            // Client certificate for mutual TLS
+	TLSKeyFile             string                 `yaml:"tls_key_file,omitempty"`             // Client private key for mutual TLS
+	TLSInsecureSkipVerify  bool                   `yaml:"tls_insecure_skip_verify,omitempty"` // Disable server certificate verification
+	ProxyURL               string                 `yaml:"proxy_url,omitempty"`                // HTTP proxy (default: HTTPS_PROXY/HTTP_PROXY)
+	Timeout                string                 `yaml:"timeout,omitempty"`                  // Request timeout, e.g. 45s (default 30s)
+	Headers                map[string]string      `yaml:"headers,omitempty"`                  // Static headers sent with every request
+}
+
+// FilePaths returns the TLS file paths referenced by the configuration, so
+// they can be bundled and rewritten together
+func (p *PocketBaseConfig) FilePaths() []*string {
+	return []*string{&p.TLSCAFile, &p.TLSCertFile, &p.TLSKeyFile}
 }
 
 // NATSConfig contains NATS-specific configuration
 type NATSConfig struct {
 	Servers     []string `yaml:"servers"`
-	AuthMethod  string   `yaml:"auth_method"`  // user_pass|token|creds
+	AuthMethod  string   `yaml:"auth_method"`  // user_pass|token|creds|nkey
 	Username    string   `yaml:"username"`     // For user_pass method
 	Password    string   `yaml:"password"`     // For user_pass method
 	Token       string   `yaml:"token"`        // For token method
 	CredsFile   string   `yaml:"creds_file"`   // For creds method
+	NKeyFile    string   `yaml:"nkey_file,omitempty"` // For nkey method (user seed file)
 	TLSEnabled  bool     `yaml:"tls_enabled"`
 	TLSVerify   bool     `yaml:"tls_verify"`
+
+	TLSCAFile     string `yaml:"tls_ca_file,omitempty"`     // PEM bundle of CAs trusted for the servers
+	TLSCertFile   string `yaml:"tls_cert_file,omitempty"`   // Client certificate for mutual TLS
+	TLSKeyFile    string `yaml:"tls_key_file,omitempty"`    // Client private key for mutual TLS
+	TLSServerName string `yaml:"tls_server_name,omitempty"` // Name verified in the server certificate
+	TLSMinVersion string `yaml:"tls_min_version,omitempty"` // 1.0|1.1|1.2|1.3 (default 1.2)
+
+	CredentialHelper *CredentialHelper `yaml:"credential_helper,omitempty"` // External command supplying credentials
+}
+
+// FilePaths returns the file paths referenced by the configuration, so they
+// can be resolved or rewritten together
+func (n *NATSConfig) FilePaths() []*string {
+	return []*string{&n.CredsFile, &n.NKeyFile, &n.TLSCAFile, &n.TLSCertFile, &n.TLSKeyFile}
+}
+
+// CredentialHelper is an external command that prints credentials as JSON on stdout,
+// used instead of the secrets stored in the context
+type CredentialHelper struct {
+	Command string            `yaml:"command"`
+	Args    []string          `yaml:"args,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty"`
 }
 
 // StoneAgeCollections defines the available Stone-Age.io collections
@@ -118,13 +161,17 @@ const (
 	NATSAuthUserPass = "user_pass"
 	NATSAuthToken    = "token"
 	NATSAuthCreds    = "creds"
+	NATSAuthNKey     = "nkey"
 )
 
 // Output format constants
 const (
-	OutputFormatJSON  = "json"
-	OutputFormatYAML  = "yaml"
-	OutputFormatTable = "table"
+	OutputFormatJSON   = "json"
+	OutputFormatYAML   = "yaml"
+	OutputFormatTable  = "table"
+	OutputFormatNDJSON = "ndjson"
+	OutputFormatCSV    = "csv"
+	OutputFormatTSV    = "tsv"
 )
 
 // PocketBase auth collection constants
@@ -134,6 +181,7 @@ const (
 	AuthCollectionEdges        = "edges"
 	AuthCollectionThings       = "things"
 	AuthCollectionServiceUsers = "service_users"
+	AuthCollectionSuperusers   = "_superusers" // PocketBase platform operators (v0.23+)
 )
 
 // ValidateAuthCollection validates a PocketBase auth collection name
@@ -144,6 +192,7 @@ func ValidateAuthCollection(collection string) error {
 		AuthCollectionEdges,
 		AuthCollectionThings,
 		AuthCollectionServiceUsers,
+		AuthCollectionSuperusers,
 	}
 
 	for _, valid := range validCollections {
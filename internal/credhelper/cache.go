@@ -0,0 +1,94 @@
+package credhelper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"flint-cli/internal/config"
+)
+
+// cacheExpiryMargin keeps credentials from being used right up to their expiry
+const cacheExpiryMargin = 30 * time.Second
+
+// cacheEntry is a cached result of a credential helper
+type cacheEntry struct {
+	key         string // Hash of the helper configuration that produced the credentials
+	credentials Credentials
+}
+
+// Helper credentials are cached in memory only, so tokens and passwords are
+// never written to disk in plaintext. Each flint process runs the helper at
+// most once per service until the credentials expire; the cache does not
+// outlive the process, so the next command runs the helper again.
+var (
+	cacheMu sync.Mutex
+	cache   = make(map[string]cacheEntry)
+)
+
+// cacheKey returns the cache key for a context's service
+func cacheKey(contextName, service string) string {
+	return contextName + "." + service
+}
+
+// Invalidate removes cached credentials, e.g. after the server rejected them
+func Invalidate(contextName, service string) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	delete(cache, cacheKey(contextName, service))
+}
+
+// loadCached returns cached credentials that are still valid for the same helper, or nil
+func loadCached(contextName, service string, helper *config.CredentialHelper) *Credentials {
+	cacheMu.Lock()
+	entry, ok := cache[cacheKey(contextName, service)]
+	cacheMu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	creds := entry.credentials
+	if entry.key != helperKey(helper) || creds.ExpiresAt == nil ||
+		time.Until(*creds.ExpiresAt) < cacheExpiryMargin {
+		return nil
+	}
+
+	return &creds
+}
+
+// storeCached caches credentials for the rest of the process. Credentials
+// without an expiry are not cached, so the helper runs every time they are needed.
+func storeCached(contextName, service string, helper *config.CredentialHelper, creds *Credentials) {
+	if creds.ExpiresAt == nil {
+		return
+	}
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	cache[cacheKey(contextName, service)] = cacheEntry{key: helperKey(helper), credentials: *creds}
+}
+
+// helperKey identifies a helper configuration, so editing it invalidates the cache
+func helperKey(helper *config.CredentialHelper) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%q", helper.Command)
+	for _, arg := range helper.Args {
+		fmt.Fprintf(hash, "%q", arg)
+	}
+
+	keys := make([]string, 0, len(helper.Env))
+	for key := range helper.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%q=%q", key, helper.Env[key])
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
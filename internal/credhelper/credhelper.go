@@ -0,0 +1,138 @@
+package credhelper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"flint-cli/internal/config"
+	"flint-cli/internal/utils"
+)
+
+// Services a credential helper can be asked for, passed in FLINT_CREDENTIAL_SERVICE
+const (
+	ServicePocketBase = "pocketbase"
+	ServiceNATS       = "nats"
+)
+
+// Environment variables set for the helper command
+const (
+	EnvService = "FLINT_CREDENTIAL_SERVICE"
+	EnvContext = "FLINT_CREDENTIAL_CONTEXT"
+)
+
+// helperTimeout bounds how long a helper may run (it may prompt on the terminal)
+const helperTimeout = 2 * time.Minute
+
+// Credentials is the JSON document a credential helper prints on stdout.
+// PocketBase helpers return a token; NATS helpers return a token, a
+// username and password, or the path of a credentials or NKey seed file.
+type Credentials struct {
+	Token     string     `json:"token,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Username  string     `json:"username,omitempty"`
+	Password  string     `json:"password,omitempty"`
+	CredsFile string     `json:"creds_file,omitempty"`
+	NKeyFile  string     `json:"nkey_file,omitempty"`
+}
+
+// Get returns the credentials for a context's service, from the cache while
+// they are valid and otherwise by running the helper
+func Get(contextName, service string, helper *config.CredentialHelper) (*Credentials, error) {
+	if creds := loadCached(contextName, service, helper); creds != nil {
+		utils.PrintDebug(fmt.Sprintf("Using cached %s credentials from credential helper", service))
+		return creds, nil
+	}
+
+	creds, err := Run(contextName, service, helper)
+	if err != nil {
+		return nil, err
+	}
+
+	storeCached(contextName, service, helper, creds)
+	return creds, nil
+}
+
+// Run executes the helper command and validates its output, bypassing the cache
+func Run(contextName, service string, helper *config.CredentialHelper) (*Credentials, error) {
+	if helper == nil || helper.Command == "" {
+		return nil, fmt.Errorf("no credential helper command configured")
+	}
+
+	utils.PrintDebug(fmt.Sprintf("Running credential helper: %s %s", helper.Command, strings.Join(helper.Args, " ")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), helperTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, helper.Command, helper.Args...)
+	cmd.Env = append(os.Environ(), EnvService+"="+service, EnvContext+"="+contextName)
+	for key, value := range helper.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	// The helper may interact with the user (e.g. a vault login prompt)
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("credential helper '%s' timed out after %s", helper.Command, helperTimeout)
+		}
+		return nil, fmt.Errorf("credential helper '%s' failed: %w", helper.Command, err)
+	}
+
+	var creds Credentials
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return nil, fmt.Errorf("credential helper '%s' returned invalid JSON: %w", helper.Command, err)
+	}
+
+	if err := creds.validate(service); err != nil {
+		return nil, fmt.Errorf("credential helper '%s' %w", helper.Command, err)
+	}
+
+	return &creds, nil
+}
+
+// validate checks that the credentials are usable for service
+func (c *Credentials) validate(service string) error {
+	switch service {
+	case ServicePocketBase:
+		if c.Token == "" {
+			return fmt.Errorf("returned no token")
+		}
+	case ServiceNATS:
+		if c.Token == "" && c.CredsFile == "" && c.NKeyFile == "" && (c.Username == "" || c.Password == "") {
+			return fmt.Errorf("returned no token, creds_file, nkey_file or username and password")
+		}
+	default:
+		return fmt.Errorf("was asked for unknown service '%s'", service)
+	}
+
+	if c.ExpiresAt != nil && !time.Now().Before(*c.ExpiresAt) {
+		return fmt.Errorf("returned credentials that expired at %s", c.ExpiresAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// NATSAuthMethod returns the NATS auth method matching the returned fields
+func (c *Credentials) NATSAuthMethod() string {
+	switch {
+	case c.CredsFile != "":
+		return config.NATSAuthCreds
+	case c.NKeyFile != "":
+		return config.NATSAuthNKey
+	case c.Token != "":
+		return config.NATSAuthToken
+	default:
+		return config.NATSAuthUserPass
+	}
+}
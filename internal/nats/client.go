@@ -3,11 +3,13 @@ package nats
 import (
 	"crypto/tls"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/nats-io/nats.go"
 	"flint-cli/internal/config"
+	"flint-cli/internal/credhelper"
 	"flint-cli/internal/utils"
 )
 
@@ -17,11 +19,17 @@ func NewClient(natsConfig *config.NATSConfig) *Client {
 		servers:          natsConfig.Servers,
 		authMethod:       natsConfig.AuthMethod,
 		username:         natsConfig.Username,
-		password:         natsConfig.Password,
-		token:            natsConfig.Token,
+		password:         Password(natsConfig),
+		token:            Token(natsConfig),
 		credsFile:        natsConfig.CredsFile,
+		nkeyFile:         natsConfig.NKeyFile,
 		tlsEnabled:       natsConfig.TLSEnabled,
 		tlsVerify:        natsConfig.TLSVerify,
+		tlsCAFile:        natsConfig.TLSCAFile,
+		tlsCertFile:      natsConfig.TLSCertFile,
+		tlsKeyFile:       natsConfig.TLSKeyFile,
+		tlsServerName:    natsConfig.TLSServerName,
+		tlsMinVersion:    natsConfig.TLSMinVersion,
 		maxReconnects:    DefaultMaxReconnects,
 		reconnectWait:    DefaultReconnectWait,
 		pingInterval:     DefaultPingInterval,
@@ -33,6 +41,21 @@ func NewClient(natsConfig *config.NATSConfig) *Client {
 // NewClientFromContext creates a NATS client from a context configuration
 func NewClientFromContext(ctx *config.Context) *Client {
 	client := NewClient(&ctx.NATS)
+
+	// A credential helper replaces the stored secrets, unless FLINT_NATS_* overrides them
+	if helper := ctx.NATS.CredentialHelper; helper != nil && os.Getenv(EnvPassword) == "" && os.Getenv(EnvToken) == "" {
+		creds, err := credhelper.Get(ctx.Name, credhelper.ServiceNATS, helper)
+		if err != nil {
+			client.helperErr = err
+			return client
+		}
+		client.authMethod = creds.NATSAuthMethod()
+		client.username = creds.Username
+		client.password = creds.Password
+		client.token = creds.Token
+		client.credsFile = creds.CredsFile
+		client.nkeyFile = creds.NKeyFile
+	}
 	
 	// Convert relative creds file path to absolute if needed
 	if client.credsFile != "" && strings.HasPrefix(client.credsFile, "./") {
@@ -51,6 +74,10 @@ func (c *Client) Connect() error {
 		return nil
 	}
 
+	if c.helperErr != nil {
+		return c.helperErr
+	}
+
 	utils.PrintDebug(fmt.Sprintf("Connecting to NATS servers: %v", c.servers))
 
 	// Build connection options
@@ -220,6 +247,17 @@ func (c *Client) buildAuthOptions() ([]nats.Option, error) {
 		utils.PrintDebug(fmt.Sprintf("Using JWT credentials file: %s", c.credsFile))
 		opts = append(opts, nats.UserCredentials(c.credsFile))
 
+	case AuthMethodNKey:
+		if c.nkeyFile == "" {
+			return nil, fmt.Errorf("NKey seed file path is required for nkey authentication")
+		}
+		utils.PrintDebug(fmt.Sprintf("Using NKey seed file: %s", c.nkeyFile))
+		opt, err := nats.NkeyOptionFromSeed(c.nkeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load NKey seed: %w", err)
+		}
+		opts = append(opts, opt)
+
 	default:
 		return nil, fmt.Errorf("unsupported authentication method: %s", c.authMethod)
 	}
@@ -231,17 +269,40 @@ func (c *Client) buildAuthOptions() ([]nats.Option, error) {
 func (c *Client) buildTLSOptions() ([]nats.Option, error) {
 	var opts []nats.Option
 
-	if c.tlsEnabled {
-		utils.PrintDebug("TLS enabled for NATS connection")
-		if c.tlsVerify {
-			utils.PrintDebug("TLS certificate verification enabled")
-			opts = append(opts, nats.Secure())
-		} else {
-			utils.PrintDebug("TLS certificate verification disabled")
-			opts = append(opts, nats.Secure(&tls.Config{
-				InsecureSkipVerify: true,
-			}))
+	if !c.tlsEnabled {
+		return opts, nil
+	}
+
+	utils.PrintDebug("TLS enabled for NATS connection")
+
+	minVersion, err := utils.TLSVersion(c.tlsMinVersion)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		MinVersion: minVersion,
+		ServerName: c.tlsServerName,
+	}
+
+	if c.tlsVerify {
+		utils.PrintDebug("TLS certificate verification enabled")
+	} else {
+		utils.PrintDebug("TLS certificate verification disabled")
+		tlsConfig.InsecureSkipVerify = true
+	}
+	opts = append(opts, nats.Secure(tlsConfig))
+
+	if c.tlsCAFile != "" {
+		utils.PrintDebug(fmt.Sprintf("Using CA bundle: %s", c.tlsCAFile))
+		opts = append(opts, nats.RootCAs(c.tlsCAFile))
+	}
+
+	if c.tlsCertFile != "" || c.tlsKeyFile != "" {
+		if c.tlsCertFile == "" || c.tlsKeyFile == "" {
+			return nil, fmt.Errorf("both a client certificate and key are required for mutual TLS")
 		}
+		utils.PrintDebug(fmt.Sprintf("Using client certificate: %s", c.tlsCertFile))
+		opts = append(opts, nats.ClientCert(c.tlsCertFile, c.tlsKeyFile))
 	}
 
 	return opts, nil
@@ -0,0 +1,59 @@
+package nats
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nats-io/nkeys"
+	"flint-cli/internal/utils"
+)
+
+// ReadCredsJWT extracts the user JWT from a NATS credentials file
+func ReadCredsJWT(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	jwt, err := nkeys.ParseDecoratedJWT(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse credentials file: %w", err)
+	}
+
+	return jwt, nil
+}
+
+// CredsExpiry returns the expiry of the user JWT in a credentials file (nil if it never expires)
+func CredsExpiry(path string) (*time.Time, error) {
+	jwt, err := ReadCredsJWT(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.JWTExpiry(jwt)
+}
+
+// NKeyPublicKey reads a user NKey seed file and returns its public key
+func NKeyPublicKey(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read NKey seed file: %w", err)
+	}
+
+	kp, err := nkeys.ParseDecoratedNKey(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse NKey seed file: %w", err)
+	}
+	defer kp.Wipe()
+
+	publicKey, err := kp.PublicKey()
+	if err != nil {
+		return "", fmt.Errorf("NKey seed file does not contain a seed: %w", err)
+	}
+	if !nkeys.IsValidPublicUserKey(publicKey) {
+		return "", fmt.Errorf("NKey seed file does not contain a user seed (public key %s)", publicKey)
+	}
+
+	return publicKey, nil
+}
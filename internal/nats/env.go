@@ -0,0 +1,29 @@
+package nats
+
+import (
+	"os"
+
+	"flint-cli/internal/config"
+)
+
+// Environment variables that supply NATS secrets without storing them in the context
+const (
+	EnvPassword = "FLINT_NATS_PASSWORD"
+	EnvToken    = "FLINT_NATS_TOKEN"
+)
+
+// Password returns the user_pass password, preferring FLINT_NATS_PASSWORD over the stored one
+func Password(natsConfig *config.NATSConfig) string {
+	if password := os.Getenv(EnvPassword); password != "" {
+		return password
+	}
+	return natsConfig.Password
+}
+
+// Token returns the auth token, preferring FLINT_NATS_TOKEN over the stored one
+func Token(natsConfig *config.NATSConfig) string {
+	if token := os.Getenv(EnvToken); token != "" {
+		return token
+	}
+	return natsConfig.Token
+}
@@ -0,0 +1,40 @@
+package nats
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"flint-cli/internal/config"
+	"flint-cli/internal/utils"
+)
+
+// CheckTLSFiles verifies that the TLS settings of a NATS configuration (with
+// resolved paths) can be loaded, so mistakes surface before connecting
+func CheckTLSFiles(natsConfig *config.NATSConfig) error {
+	if _, err := utils.TLSVersion(natsConfig.TLSMinVersion); err != nil {
+		return err
+	}
+
+	if natsConfig.TLSCAFile != "" {
+		data, err := os.ReadFile(natsConfig.TLSCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(data) {
+			return fmt.Errorf("no certificates found in CA bundle %s", natsConfig.TLSCAFile)
+		}
+	}
+
+	if (natsConfig.TLSCertFile == "") != (natsConfig.TLSKeyFile == "") {
+		return fmt.Errorf("both a client certificate and key are required for mutual TLS")
+	}
+	if natsConfig.TLSCertFile != "" {
+		if _, err := tls.LoadX509KeyPair(natsConfig.TLSCertFile, natsConfig.TLSKeyFile); err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+	}
+
+	return nil
+}
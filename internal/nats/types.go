@@ -17,10 +17,17 @@ type Client struct {
 	password   string
 	token      string
 	credsFile  string
+	nkeyFile   string
+	helperErr  error // Credential helper failure, reported by Connect
 	
 	// TLS configuration
-	tlsEnabled bool
-	tlsVerify  bool
+	tlsEnabled    bool
+	tlsVerify     bool
+	tlsCAFile     string
+	tlsCertFile   string
+	tlsKeyFile    string
+	tlsServerName string
+	tlsMinVersion string
 	
 	// Connection options
 	maxReconnects    int
@@ -87,6 +94,7 @@ const (
 	AuthMethodUserPass = "user_pass"
 	AuthMethodToken    = "token"
 	AuthMethodCreds    = "creds"
+	AuthMethodNKey     = "nkey"
 )
 
 // Default connection configuration values
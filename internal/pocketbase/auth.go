@@ -3,6 +3,7 @@ package pocketbase
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 
 	"flint-cli/internal/config"
@@ -20,10 +21,17 @@ type AuthResponse struct {
 type AuthRequest struct {
 	Identity string `json:"identity"`
 	Password string `json:"password"`
+	MFAID    string `json:"mfaId,omitempty"`
 }
 
 // Authenticate performs authentication against a specific collection
 func (c *Client) Authenticate(collection, identity, password string) (*AuthResponse, error) {
+	return c.AuthenticateWithPassword(collection, identity, password, "")
+}
+
+// AuthenticateWithPassword performs a password login. mfaID is set when the
+// password is the second factor of a multi-factor login.
+func (c *Client) AuthenticateWithPassword(collection, identity, password, mfaID string) (*AuthResponse, error) {
 	// Validate collection
 	if err := config.ValidateAuthCollection(collection); err != nil {
 		return nil, fmt.Errorf("invalid auth collection: %w", err)
@@ -41,14 +49,22 @@ func (c *Client) Authenticate(collection, identity, password string) (*AuthRespo
 	authData := AuthRequest{
 		Identity: identity,
 		Password: password,
+		MFAID:    mfaID,
 	}
 
 	// Make authentication request
 	endpoint := fmt.Sprintf("collections/%s/auth-with-password", collection)
+	if mfaID != "" {
+		// PocketBase reads mfaId from the query string; it is sent in the body as well
+		endpoint += "?mfaId=" + url.QueryEscape(mfaID)
+	}
 	
 	utils.PrintDebug(fmt.Sprintf("Authenticating with collection: %s", collection))
 	
 	resp, err := c.makeRequest("POST", endpoint, authData)
+	if mfaErr := mfaRequired(resp); mfaErr != nil {
+		return nil, mfaErr
+	}
 	if err != nil {
 		return nil, fmt.Errorf("authentication failed: %w", err)
 	}
@@ -60,8 +76,7 @@ func (c *Client) Authenticate(collection, identity, password string) (*AuthRespo
 	}
 
 	// Set authentication token
-	c.SetAuthToken(authResp.Token)
-	c.authRecord = authResp.Record
+	c.setAuth(authResp.Token, authResp.Record, TokenExpiry(authResp.Token))
 
 	utils.PrintDebug("Authentication successful")
 	
@@ -89,14 +104,48 @@ func (c *Client) RefreshAuth(collection string) (*AuthResponse, error) {
 	}
 
 	// Update authentication
-	c.SetAuthToken(authResp.Token)
-	c.authRecord = authResp.Record
+	c.setAuth(authResp.Token, authResp.Record, TokenExpiry(authResp.Token))
 
 	utils.PrintDebug("Authentication refreshed successfully")
 	
 	return &authResp, nil
 }
 
+// Impersonate mints a non-refreshable token for another auth record. Requires a
+// superuser session; duration 0 uses the collection's default token duration.
+func (c *Client) Impersonate(collection, recordID string, duration time.Duration) (*AuthResponse, error) {
+	if !c.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated")
+	}
+	if err := config.ValidateAuthCollection(collection); err != nil {
+		return nil, fmt.Errorf("invalid auth collection: %w", err)
+	}
+	if recordID == "" {
+		return nil, fmt.Errorf("record ID is required")
+	}
+
+	endpoint := fmt.Sprintf("collections/%s/impersonate/%s", collection, recordID)
+
+	utils.PrintDebug(fmt.Sprintf("Impersonating %s/%s", collection, recordID))
+
+	body := map[string]interface{}{}
+	if duration > 0 {
+		body["duration"] = int64(duration.Seconds())
+	}
+
+	resp, err := c.makeRequest("POST", endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("impersonation failed: %w", err)
+	}
+
+	var authResp AuthResponse
+	if err := json.Unmarshal(resp.Body(), &authResp); err != nil {
+		return nil, fmt.Errorf("failed to parse impersonation response: %w", err)
+	}
+
+	return &authResp, nil
+}
+
 // ValidateAuth checks if the current authentication is valid
 func (c *Client) ValidateAuth(collection string) error {
 	if !c.IsAuthenticated() {
@@ -120,11 +169,12 @@ func (c *Client) GetAuthenticatedUser() (map[string]interface{}, error) {
 		return nil, fmt.Errorf("not authenticated")
 	}
 
-	if c.authRecord == nil {
+	authRecord := c.GetAuthRecord()
+	if authRecord == nil {
 		return nil, fmt.Errorf("no authentication record available")
 	}
 
-	return c.authRecord, nil
+	return authRecord, nil
 }
 
 // ValidateOrganizationAccess validates that the authenticated user belongs to the specified organization
@@ -369,9 +419,7 @@ func UpdateAuthContextFromResponse(ctx *config.Context, authResp *AuthResponse,
 	ctx.PocketBase.AuthToken = authResp.Token
 	ctx.PocketBase.AuthRecord = authResp.Record
 	
-	// Set expiration (PocketBase tokens typically last 7 days)
-	expiresAt := time.Now().Add(7 * 24 * time.Hour)
-	ctx.PocketBase.AuthExpires = &expiresAt
+	ctx.PocketBase.AuthExpires = TokenExpiry(authResp.Token)
 
 	// Set organization if provided
 	if organizationID != "" {
@@ -381,19 +429,57 @@ func UpdateAuthContextFromResponse(ctx *config.Context, authResp *AuthResponse,
 	return nil
 }
 
+// TokenExpiry returns the expiry of a PocketBase token, read from its exp claim.
+// Falls back to 7 days from now (the PocketBase default) if the token has none.
+func TokenExpiry(token string) *time.Time {
+	if expires, err := utils.JWTExpiry(token); err == nil && expires != nil {
+		return expires
+	}
+	expiresAt := time.Now().Add(7 * 24 * time.Hour)
+	return &expiresAt
+}
+
+// HasSession reports whether a context has a session token: stored, from
+// FLINT_PB_TOKEN, or supplied by a credential helper
+func HasSession(ctx *config.Context) bool {
+	return EnvAuthToken() != "" || ctx.PocketBase.CredentialHelper != nil || ctx.PocketBase.AuthToken != ""
+}
+
+// sessionExpiry returns the expiry of the token NewClientFromContext would use.
+// Helper tokens are fetched on demand, so no expiry is known in advance.
+func sessionExpiry(ctx *config.Context) *time.Time {
+	if token := EnvAuthToken(); token != "" {
+		return TokenExpiry(token)
+	}
+	if ctx.PocketBase.CredentialHelper != nil {
+		return nil
+	}
+	return ctx.PocketBase.AuthExpires
+}
+
+// CanRefreshAuth checks if a context has a session that can still be refreshed
+func CanRefreshAuth(ctx *config.Context) bool {
+	if !HasSession(ctx) {
+		return false
+	}
+	expires := sessionExpiry(ctx)
+	return expires == nil || time.Now().Before(*expires)
+}
+
 // IsAuthValid checks if the authentication in a context is still valid
 func IsAuthValid(ctx *config.Context) bool {
-	if ctx.PocketBase.AuthToken == "" {
+	if !HasSession(ctx) {
 		return false
 	}
 
-	if ctx.PocketBase.AuthExpires == nil {
+	expires := sessionExpiry(ctx)
+	if expires == nil {
 		// No expiration set, assume valid for backward compatibility
 		return true
 	}
 
 	// Check if token has expired (with 5-minute buffer)
-	return time.Now().Before(ctx.PocketBase.AuthExpires.Add(-5 * time.Minute))
+	return time.Now().Before(expires.Add(-5 * time.Minute))
 }
 
 // GetOrganizationInfo extracts organization information from auth record
@@ -431,6 +517,8 @@ func GetCollectionDisplayName(collection string) string {
 		return "Things (Individual Device Authentication)"
 	case config.AuthCollectionServiceUsers:
 		return "Service Users (System Service Accounts)"
+	case config.AuthCollectionSuperusers:
+		return "Superusers (Platform Operators)"
 	default:
 		return collection
 	}
@@ -444,6 +532,7 @@ func ValidateAuthCollection(collection string) error {
 		config.AuthCollectionEdges,
 		config.AuthCollectionThings,
 		config.AuthCollectionServiceUsers,
+		config.AuthCollectionSuperusers,
 	}
 
 	for _, valid := range validCollections {
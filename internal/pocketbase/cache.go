@@ -0,0 +1,142 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adrg/xdg"
+	"flint-cli/internal/utils"
+)
+
+// SchemaCacheTTL is how long cached collection schemas are used before they are fetched again
+const SchemaCacheTTL = time.Hour
+
+// ErrSchemaForbidden is returned when the session may not read collection schemas
+var ErrSchemaForbidden = errors.New("reading collection schemas requires superuser access")
+
+// schemaCacheEntry is the on-disk form of a context's collection schemas
+type schemaCacheEntry struct {
+	URL         string       `json:"url"`                // Server the schemas came from
+	Identity    string       `json:"identity,omitempty"` // Auth record the schemas were read as
+	Fetched     time.Time    `json:"fetched"`
+	Forbidden   bool         `json:"forbidden,omitempty"` // The server refused to list collections
+	Collections []Collection `json:"collections,omitempty"`
+}
+
+// SchemaCacheDir returns the directory holding cached collection schemas
+func SchemaCacheDir() string {
+	return filepath.Join(xdg.CacheHome, "flint", "schemas")
+}
+
+// schemaCachePath returns the schema cache file for a context
+func schemaCachePath(contextName string) string {
+	return filepath.Join(SchemaCacheDir(), contextName+".json")
+}
+
+// CachedCollections returns the collection schemas for a context, fetching
+// them when the cache is missing, stale, from another server or read as
+// another user. A refusal (non-superuser sessions) is cached too, as
+// ErrSchemaForbidden.
+func (c *Client) CachedCollections(contextName string) ([]Collection, error) {
+	identity := c.sessionIdentity()
+	if entry := loadSchemaCache(contextName, c.baseURL, identity); entry != nil {
+		utils.PrintDebug(fmt.Sprintf("Using collection schemas cached at %s", entry.Fetched.Local().Format(time.RFC3339)))
+		if entry.Forbidden {
+			return nil, ErrSchemaForbidden
+		}
+		return entry.Collections, nil
+	}
+
+	entry := schemaCacheEntry{URL: c.baseURL, Identity: identity, Fetched: time.Now()}
+	collections, err := c.GetCollections()
+	var pbErr *PocketBaseError
+	if errors.As(err, &pbErr) && pbErr.StatusCode == http.StatusForbidden {
+		entry.Forbidden = true
+	} else if err != nil {
+		return nil, err
+	}
+	entry.Collections = collections
+
+	if err := storeSchemaCache(contextName, entry); err != nil {
+		utils.PrintDebug(fmt.Sprintf("Failed to cache collection schemas: %v", err))
+	}
+	if entry.Forbidden {
+		return nil, ErrSchemaForbidden
+	}
+	return collections, nil
+}
+
+// InvalidateSchemaCache removes the cached collection schemas of a context
+func InvalidateSchemaCache(contextName string) error {
+	if err := os.Remove(schemaCachePath(contextName)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove cached schemas: %w", err)
+	}
+	return nil
+}
+
+// sessionIdentity returns the auth collection and record ID of the session
+// token, so schemas read as one user are not reused for another
+func (c *Client) sessionIdentity() string {
+	claims, err := utils.JWTClaims(c.GetAuthToken())
+	if err != nil {
+		return ""
+	}
+	collection, _ := claims["collectionId"].(string)
+	id, _ := claims["id"].(string)
+	if id == "" {
+		return ""
+	}
+	return collection + "/" + id
+}
+
+// loadSchemaCache returns the cached schemas of a context if they are fresh,
+// from baseURL and read as identity, or nil
+func loadSchemaCache(contextName, baseURL, identity string) *schemaCacheEntry {
+	data, err := os.ReadFile(schemaCachePath(contextName))
+	if err != nil {
+		return nil
+	}
+
+	var entry schemaCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil
+	}
+	if entry.URL != baseURL || entry.Identity != identity || time.Since(entry.Fetched) > SchemaCacheTTL {
+		return nil
+	}
+	return &entry
+}
+
+// storeSchemaCache writes a context's schemas to the cache
+func storeSchemaCache(contextName string, entry schemaCacheEntry) error {
+	if err := os.MkdirAll(SchemaCacheDir(), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	// Write atomically so concurrent flint processes never read a partial file
+	tmp, err := os.CreateTemp(SchemaCacheDir(), ".schemas-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), schemaCachePath(contextName))
+}
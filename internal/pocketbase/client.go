@@ -3,20 +3,47 @@ package pocketbase
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"flint-cli/internal/config"
+	"flint-cli/internal/credhelper"
 	"flint-cli/internal/utils"
 )
 
+// Sources of session tokens that are not stored in the context
+const (
+	TokenSourceEnv    = EnvToken
+	TokenSourceHelper = "credential helper"
+)
+
+// TokenRefreshWindow is how long before expiry an auto-refreshing client renews its session token
+const TokenRefreshWindow = 1 * time.Hour
+
 // Client represents a PocketBase HTTP client
 type Client struct {
-	httpClient *resty.Client
-	baseURL    string
-	authToken  string
-	authRecord map[string]interface{}
+	httpClient  *resty.Client
+	baseURL     string
+	mu          sync.RWMutex
+	authToken   string
+	authRecord  map[string]interface{}
+	authExpires *time.Time
+	tokenSource string // TokenSourceEnv or TokenSourceHelper for tokens not from the context
+	helperErr   error  // Credential helper failure, reported on the first request
+	helperOnce  sync.Once
+	configErr   error  // Invalid transport settings, reported on the first request
+
+	// Credential helper (see config.CredentialHelper)
+	helper      *config.CredentialHelper
+	contextName string
+
+	// Auto-refresh (see EnableAutoRefresh)
+	manager   *config.Manager
+	context   *config.Context
+	refreshMu sync.Mutex
 }
 
 // NewClient creates a new PocketBase client
@@ -28,7 +55,7 @@ func NewClient(baseURL string) *Client {
 	client.SetHeader("User-Agent", "flint-cli/0.1.0")
 	
 	// Set timeout
-	client.SetTimeout(30 * time.Second)
+	client.SetTimeout(DefaultTimeout)
 	
 	// Enable debug mode if configured
 	if config.Global.Debug {
@@ -43,66 +70,139 @@ func NewClient(baseURL string) *Client {
 
 // NewClientFromContext creates a PocketBase client from a context configuration
 func NewClientFromContext(ctx *config.Context) *Client {
-	client := NewClient(ctx.PocketBase.URL)
-	
+	client, err := NewClientWithConfig(&ctx.PocketBase)
+	client.configErr = err
+
+	// A token from the environment takes precedence over the stored session
+	if token := EnvAuthToken(); token != "" {
+		utils.PrintDebug(fmt.Sprintf("Using session token from %s", EnvToken))
+		client.setAuth(token, nil, TokenExpiry(token))
+		client.tokenSource = TokenSourceEnv
+		return client
+	}
+
+	// Then an external credential helper, run when the token is first needed
+	if helper := ctx.PocketBase.CredentialHelper; helper != nil {
+		client.helper = helper
+		client.contextName = ctx.Name
+		client.tokenSource = TokenSourceHelper
+		return client
+	}
+
 	// Set authentication if available
 	if ctx.PocketBase.AuthToken != "" {
 		client.SetAuthToken(ctx.PocketBase.AuthToken)
 		client.authRecord = ctx.PocketBase.AuthRecord
+		client.authExpires = ctx.PocketBase.AuthExpires
 	}
 	
 	return client
 }
 
+// EnableAutoRefresh keeps the session of ctx alive: the token is refreshed when it
+// is about to expire and once more if the server rejects it with 401. Refreshed
+// tokens are written back to the context through manager.
+func (c *Client) EnableAutoRefresh(manager *config.Manager, ctx *config.Context) {
+	// Impersonation tokens can't be refreshed, and environment tokens are never saved
+	if ctx.PocketBase.ImpersonatedBy != "" || c.tokenSource != "" {
+		return
+	}
+	c.manager = manager
+	c.context = ctx
+}
+
 // SetAuthToken sets the authentication token for requests
 func (c *Client) SetAuthToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.authToken = token
+}
+
+// setAuth updates the token, record and expiry after (re)authentication
+func (c *Client) setAuth(token string, record map[string]interface{}, expires *time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.authToken = token
-	c.httpClient.SetAuthToken(token)
+	c.authRecord = record
+	c.authExpires = expires
 }
 
 // GetAuthToken returns the current authentication token
 func (c *Client) GetAuthToken() string {
+	c.ensureHelperToken()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.authToken
 }
 
 // GetAuthRecord returns the current authentication record
 func (c *Client) GetAuthRecord() map[string]interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.authRecord
 }
 
+// TokenSource returns where the session token came from when it is not the
+// context's stored session (TokenSourceEnv or TokenSourceHelper), or ""
+func (c *Client) TokenSource() string {
+	return c.tokenSource
+}
+
+// CredentialError runs the credential helper if it hasn't run yet and
+// returns its error, if any
+func (c *Client) CredentialError() error {
+	return c.ensureHelperToken()
+}
+
+// ensureHelperToken runs the credential helper the first time the session
+// token is needed, so commands that never reach PocketBase don't run it
+func (c *Client) ensureHelperToken() error {
+	if c.helper == nil {
+		return nil
+	}
+	c.helperOnce.Do(func() {
+		c.helperErr = c.loadHelperToken(false)
+	})
+	return c.helperErr
+}
+
+// loadHelperToken fetches the session token from the credential helper.
+// With fresh set, cached credentials are discarded first.
+func (c *Client) loadHelperToken(fresh bool) error {
+	if fresh {
+		credhelper.Invalidate(c.contextName, credhelper.ServicePocketBase)
+	}
+
+	creds, err := credhelper.Get(c.contextName, credhelper.ServicePocketBase, c.helper)
+	if err != nil {
+		return err
+	}
+
+	expires := creds.ExpiresAt
+	if expires == nil {
+		expires = TokenExpiry(creds.Token)
+	}
+	c.setAuth(creds.Token, nil, expires)
+	return nil
+}
+
 // IsAuthenticated checks if the client has a valid authentication token
 func (c *Client) IsAuthenticated() bool {
-	return c.authToken != ""
+	return c.GetAuthToken() != ""
 }
 
 // makeRequest performs an HTTP request with error handling
 func (c *Client) makeRequest(method, endpoint string, body interface{}) (*resty.Response, error) {
-	url := fmt.Sprintf("%s/api/%s", c.baseURL, endpoint)
-	
-	utils.PrintDebug(fmt.Sprintf("Making %s request to %s", method, url))
-	
-	var resp *resty.Response
-	var err error
-	
-	switch method {
-	case "GET":
-		resp, err = c.httpClient.R().Get(url)
-	case "POST":
-		resp, err = c.httpClient.R().SetBody(body).Post(url)
-	case "PATCH":
-		resp, err = c.httpClient.R().SetBody(body).Patch(url)
-	case "DELETE":
-		resp, err = c.httpClient.R().Delete(url)
-	default:
-		return nil, fmt.Errorf("unsupported HTTP method: %s", method)
-	}
-	
+	resp, err := c.execute(method, endpoint, func(req *resty.Request) {
+		if body != nil {
+			req.SetBody(body)
+		}
+	})
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
 	
-	utils.PrintDebug(fmt.Sprintf("Response status: %d", resp.StatusCode()))
-	
 	// Handle HTTP errors
 	if resp.StatusCode() >= 400 {
 		return resp, NewPocketBaseError(resp)
@@ -111,23 +211,173 @@ func (c *Client) makeRequest(method, endpoint string, body interface{}) (*resty.
 	return resp, nil
 }
 
+// execute sends a request through the auto-refresh logic. Only transport errors
+// are returned; HTTP error statuses are left to the caller.
+func (c *Client) execute(method, endpoint string, prepare func(*resty.Request)) (*resty.Response, error) {
+	if err := c.ensureHelperToken(); err != nil {
+		return nil, err
+	}
+
+	autoRefresh := c.manager != nil && c.IsAuthenticated() && !isAuthEndpoint(endpoint)
+
+	// Renew the token before it expires
+	if autoRefresh && c.tokenNeedsRefresh() {
+		if err := c.refreshSession(c.GetAuthToken()); err != nil {
+			utils.PrintWarning(fmt.Sprintf("Failed to refresh session token: %v", err))
+		}
+	}
+
+	token := c.GetAuthToken()
+	resp, err := c.send(method, endpoint, prepare)
+	if err != nil {
+		return nil, err
+	}
+
+	// The token may have been revoked or expired early; refresh and retry once
+	if autoRefresh && resp.StatusCode() == http.StatusUnauthorized {
+		utils.PrintDebug("Session token rejected, refreshing and retrying")
+		if refreshErr := c.refreshSession(token); refreshErr != nil {
+			utils.PrintDebug(fmt.Sprintf("Session refresh failed: %v", refreshErr))
+			return resp, nil
+		}
+		return c.send(method, endpoint, prepare)
+	}
+
+	// A cached helper token may have been revoked; ask the helper again and retry once
+	if c.helper != nil && resp.StatusCode() == http.StatusUnauthorized && !isAuthEndpoint(endpoint) {
+		utils.PrintDebug("Helper token rejected, running the credential helper again")
+		if err := c.loadHelperToken(true); err != nil {
+			return nil, err
+		}
+		return c.send(method, endpoint, prepare)
+	}
+
+	return resp, nil
+}
+
+// send performs a single HTTP request with the current token
+func (c *Client) send(method, endpoint string, prepare func(*resty.Request)) (*resty.Response, error) {
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+
+	url := fmt.Sprintf("%s/api/%s", c.baseURL, endpoint)
+	
+	utils.PrintDebug(fmt.Sprintf("Making %s request to %s", method, url))
+
+	req := c.httpClient.R()
+	if token := c.GetAuthToken(); token != "" {
+		req.SetAuthToken(token)
+	}
+	if prepare != nil {
+		prepare(req)
+	}
+
+	resp, err := req.Execute(method, url)
+	if err != nil {
+		return nil, err
+	}
+	
+	utils.PrintDebug(fmt.Sprintf("Response status: %d", resp.StatusCode()))
+	
+	return resp, nil
+}
+
+// tokenNeedsRefresh reports whether the token expires within TokenRefreshWindow
+func (c *Client) tokenNeedsRefresh() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.authExpires != nil && time.Until(*c.authExpires) < TokenRefreshWindow
+}
+
+// refreshSession replaces staleToken with a fresh one and saves it to the context.
+// The refresh request is sent without holding the context lock, since it can take
+// longer than the lock timeout; the lock is taken only to save the new token. If
+// another process saved a fresh token meanwhile, that token is kept instead.
+func (c *Client) refreshSession(staleToken string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	// Another goroutine refreshed while we waited
+	if c.GetAuthToken() != staleToken {
+		return nil
+	}
+
+	authResp, err := c.RefreshAuth(c.context.PocketBase.AuthCollection)
+	if err != nil {
+		return err
+	}
+
+	updated, err := c.manager.UpdateContext(c.context.Name, func(stored *config.Context) error {
+		pb := stored.PocketBase
+		if pb.AuthToken != "" && pb.AuthToken != staleToken &&
+			(pb.AuthExpires == nil || time.Until(*pb.AuthExpires) >= TokenRefreshWindow) {
+			utils.PrintDebug("Using session token refreshed by another process")
+			return nil
+		}
+		return UpdateAuthContextFromResponse(stored, authResp, "")
+	})
+	if err != nil {
+		return err
+	}
+
+	pb := updated.PocketBase
+	c.setAuth(pb.AuthToken, pb.AuthRecord, pb.AuthExpires)
+
+	// Keep the caller's copy in sync so a later SaveContext doesn't write the old token
+	c.context.PocketBase.AuthToken = pb.AuthToken
+	c.context.PocketBase.AuthRecord = pb.AuthRecord
+	c.context.PocketBase.AuthExpires = pb.AuthExpires
+
+	utils.PrintDebug("Session token refreshed")
+	return nil
+}
+
+// RefreshSession refreshes the session token now and saves it to the context.
+// Requires EnableAutoRefresh.
+func (c *Client) RefreshSession() error {
+	if c.manager == nil {
+		return fmt.Errorf("session refresh is not available for this client")
+	}
+	return c.refreshSession(c.GetAuthToken())
+}
+
+// isAuthEndpoint reports whether endpoint is an authentication call that must not trigger a refresh
+func isAuthEndpoint(endpoint string) bool {
+	return strings.Contains(endpoint, "/auth-")
+}
+
 // GetHealth checks the PocketBase server health
 func (c *Client) GetHealth() error {
+	_, err := c.GetHealthInfo()
+	return err
+}
+
+// GetHealthInfo checks the PocketBase server health and reports latency and server time
+func (c *Client) GetHealthInfo() (*HealthInfo, error) {
+	start := time.Now()
 	resp, err := c.makeRequest("GET", "health", nil)
 	if err != nil {
-		return fmt.Errorf("health check failed: %w", err)
+		return nil, fmt.Errorf("health check failed: %w", err)
 	}
 	
 	if resp.StatusCode() != 200 {
-		return fmt.Errorf("server returned status %d", resp.StatusCode())
+		return nil, fmt.Errorf("server returned status %d", resp.StatusCode())
+	}
+
+	info := &HealthInfo{Latency: time.Since(start)}
+	if date := resp.Header().Get("Date"); date != "" {
+		if serverTime, err := http.ParseTime(date); err == nil {
+			info.ServerTime = &serverTime
+		}
 	}
 	
-	return nil
+	return info, nil
 }
 
 // GetCollections returns available collections from PocketBase
 func (c *Client) GetCollections() ([]Collection, error) {
-	resp, err := c.makeRequest("GET", "collections", nil)
+	resp, err := c.makeRequest("GET", fmt.Sprintf("collections?perPage=%d", MaxPerPage), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get collections: %w", err)
 	}
@@ -152,8 +402,10 @@ func (c *Client) ListRecords(collection string, options *ListOptions) (*RecordsL
 	endpoint := fmt.Sprintf("collections/%s/records", collection)
 	
 	// Add query parameters
-	req := c.httpClient.R()
-	if options != nil {
+	resp, err := c.execute("GET", endpoint, func(req *resty.Request) {
+		if options == nil {
+			return
+		}
 		if options.Page > 0 {
 			req.SetQueryParam("page", fmt.Sprintf("%d", options.Page))
 		}
@@ -172,10 +424,10 @@ func (c *Client) ListRecords(collection string, options *ListOptions) (*RecordsL
 		if len(options.Expand) > 0 {
 			req.SetQueryParam("expand", strings.Join(options.Expand, ","))
 		}
-	}
-	
-	url := fmt.Sprintf("%s/api/%s", c.baseURL, endpoint)
-	resp, err := req.Get(url)
+		if options.SkipTotal {
+			req.SetQueryParam("skipTotal", "1")
+		}
+	})
 	
 	if err != nil {
 		return nil, fmt.Errorf("failed to list records: %w", err)
@@ -201,13 +453,11 @@ func (c *Client) GetRecord(collection, id string, expand []string) (map[string]i
 	
 	endpoint := fmt.Sprintf("collections/%s/records/%s", collection, id)
 	
-	req := c.httpClient.R()
-	if len(expand) > 0 {
-		req.SetQueryParam("expand", strings.Join(expand, ","))
-	}
-	
-	url := fmt.Sprintf("%s/api/%s", c.baseURL, endpoint)
-	resp, err := req.Get(url)
+	resp, err := c.execute("GET", endpoint, func(req *resty.Request) {
+		if len(expand) > 0 {
+			req.SetQueryParam("expand", strings.Join(expand, ","))
+		}
+	})
 	
 	if err != nil {
 		return nil, fmt.Errorf("failed to get record: %w", err)
@@ -289,11 +539,12 @@ func (c *Client) UpdateCurrentOrganization(organizationID string) error {
 		return fmt.Errorf("authentication required")
 	}
 	
-	if c.authRecord == nil {
+	authRecord := c.GetAuthRecord()
+	if authRecord == nil {
 		return fmt.Errorf("no authentication record available")
 	}
 	
-	userID, ok := c.authRecord["id"].(string)
+	userID, ok := authRecord["id"].(string)
 	if !ok {
 		return fmt.Errorf("invalid authentication record: missing user ID")
 	}
@@ -0,0 +1,20 @@
+package pocketbase
+
+import (
+	"os"
+	"strings"
+)
+
+// Environment variables for non-interactive (CI) authentication
+const (
+	// EnvPassword supplies the password for 'flint auth pb'
+	EnvPassword = "FLINT_PB_PASSWORD"
+	// EnvToken supplies a pre-issued session token that is used instead of the
+	// context's stored session and is never written to disk
+	EnvToken = "FLINT_PB_TOKEN"
+)
+
+// EnvAuthToken returns the session token from FLINT_PB_TOKEN, if set
+func EnvAuthToken() string {
+	return strings.TrimSpace(os.Getenv(EnvToken))
+}
@@ -0,0 +1,14 @@
+package pocketbase
+
+import "strings"
+
+// filterEscaper escapes the characters that are special inside a quoted
+// PocketBase filter string
+var filterEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// QuoteFilterString returns s as a double-quoted PocketBase filter literal.
+// Only backslashes and quotes are escaped; unlike Go's %q, other characters
+// are kept as they are, since the filter syntax has no \u or \x escapes.
+func QuoteFilterString(s string) string {
+	return `"` + filterEscaper.Replace(s) + `"`
+}
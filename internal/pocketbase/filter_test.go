@@ -0,0 +1,25 @@
+package pocketbase
+
+import "testing"
+
+func TestQuoteFilterString(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"edge_gateway_01", `"edge_gateway_01"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`C:\certs`, `"C:\\certs"`},
+		{"Zürich ✓", `"Zürich ✓"`},
+		{"tab\there", "\"tab\there\""},
+		{"", `""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := QuoteFilterString(tt.value); got != tt.want {
+				t.Errorf("QuoteFilterString(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package pocketbase
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"flint-cli/internal/config"
+	"flint-cli/internal/utils"
+)
+
+// AuthMethods lists the authentication methods enabled for a collection
+type AuthMethods struct {
+	Password       bool
+	IdentityFields []string
+	OAuth2         []OAuth2Provider
+	MFA            bool
+	OTP            bool
+}
+
+// OAuth2Provider is an OAuth2 provider configured in PocketBase, with the
+// state and PKCE values generated for one login attempt
+type OAuth2Provider struct {
+	Name                string `json:"name"`
+	DisplayName         string `json:"displayName"`
+	State               string `json:"state"`
+	AuthURL             string `json:"authURL"`
+	CodeVerifier        string `json:"codeVerifier"`
+	CodeChallenge       string `json:"codeChallenge"`
+	CodeChallengeMethod string `json:"codeChallengeMethod"`
+}
+
+// authMethodsResponse covers both the current and the pre-0.23 auth-methods formats
+type authMethodsResponse struct {
+	Password *struct {
+		Enabled        bool     `json:"enabled"`
+		IdentityFields []string `json:"identityFields"`
+	} `json:"password"`
+	OAuth2 *struct {
+		Enabled   bool             `json:"enabled"`
+		Providers []OAuth2Provider `json:"providers"`
+	} `json:"oauth2"`
+	MFA *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"mfa"`
+	OTP *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"otp"`
+
+	// Legacy fields (authUrl is matched case-insensitively into AuthURL)
+	UsernamePassword bool             `json:"usernamePassword"`
+	EmailPassword    bool             `json:"emailPassword"`
+	AuthProviders    []OAuth2Provider `json:"authProviders"`
+}
+
+// OAuth2Request represents an auth-with-oauth2 request
+type OAuth2Request struct {
+	Provider     string `json:"provider"`
+	Code         string `json:"code"`
+	CodeVerifier string `json:"codeVerifier"`
+	RedirectURL  string `json:"redirectURL"`
+}
+
+// ListAuthMethods returns the authentication methods enabled for a collection
+func (c *Client) ListAuthMethods(collection string) (*AuthMethods, error) {
+	if err := config.ValidateAuthCollection(collection); err != nil {
+		return nil, fmt.Errorf("invalid auth collection: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("collections/%s/auth-methods", collection)
+	resp, err := c.makeRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auth methods: %w", err)
+	}
+
+	var raw authMethodsResponse
+	if err := json.Unmarshal(resp.Body(), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse auth methods response: %w", err)
+	}
+
+	methods := &AuthMethods{}
+	if raw.Password != nil {
+		methods.Password = raw.Password.Enabled
+		methods.IdentityFields = raw.Password.IdentityFields
+	} else {
+		methods.Password = raw.UsernamePassword || raw.EmailPassword
+	}
+	if raw.OAuth2 != nil {
+		if raw.OAuth2.Enabled {
+			methods.OAuth2 = raw.OAuth2.Providers
+		}
+	} else {
+		methods.OAuth2 = raw.AuthProviders
+	}
+	methods.MFA = raw.MFA != nil && raw.MFA.Enabled
+	methods.OTP = raw.OTP != nil && raw.OTP.Enabled
+
+	return methods, nil
+}
+
+// FindOAuth2Provider returns the named OAuth2 provider (case-insensitive)
+func (m *AuthMethods) FindOAuth2Provider(name string) (*OAuth2Provider, error) {
+	var names []string
+	for i := range m.OAuth2 {
+		if strings.EqualFold(m.OAuth2[i].Name, name) {
+			return &m.OAuth2[i], nil
+		}
+		names = append(names, m.OAuth2[i].Name)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no OAuth2 providers are enabled for this collection")
+	}
+	return nil, fmt.Errorf("OAuth2 provider '%s' is not enabled. Available providers: %s",
+		name, strings.Join(names, ", "))
+}
+
+// AuthorizationURL returns the provider's authorization URL with redirectURL filled in
+func (p *OAuth2Provider) AuthorizationURL(redirectURL string) (string, error) {
+	authURL, err := url.Parse(p.AuthURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization URL for provider '%s': %w", p.Name, err)
+	}
+
+	query := authURL.Query()
+	query.Set("redirect_uri", redirectURL)
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+// VerifyPKCE checks that the provider's code challenge matches its code verifier
+// and that the authorization URL carries the same challenge and state
+func (p *OAuth2Provider) VerifyPKCE() error {
+	if p.CodeVerifier == "" || p.CodeChallenge == "" {
+		return fmt.Errorf("provider '%s' did not supply PKCE parameters", p.Name)
+	}
+	if p.State == "" {
+		return fmt.Errorf("provider '%s' did not supply a state parameter", p.Name)
+	}
+
+	switch strings.ToUpper(p.CodeChallengeMethod) {
+	case "S256":
+		sum := sha256.Sum256([]byte(p.CodeVerifier))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != p.CodeChallenge {
+			return fmt.Errorf("PKCE code challenge does not match the code verifier")
+		}
+	case "PLAIN":
+		if p.CodeVerifier != p.CodeChallenge {
+			return fmt.Errorf("PKCE code challenge does not match the code verifier")
+		}
+	default:
+		return fmt.Errorf("unsupported PKCE method '%s'", p.CodeChallengeMethod)
+	}
+
+	authURL, err := url.Parse(p.AuthURL)
+	if err != nil {
+		return fmt.Errorf("invalid authorization URL for provider '%s': %w", p.Name, err)
+	}
+	query := authURL.Query()
+	if challenge := query.Get("code_challenge"); challenge != "" && challenge != p.CodeChallenge {
+		return fmt.Errorf("authorization URL carries a different PKCE code challenge")
+	}
+	if state := query.Get("state"); state != "" && state != p.State {
+		return fmt.Errorf("authorization URL carries a different state")
+	}
+
+	return nil
+}
+
+// AuthenticateWithOAuth2 exchanges an OAuth2 authorization code for a PocketBase session
+func (c *Client) AuthenticateWithOAuth2(collection string, provider *OAuth2Provider, code, redirectURL string) (*AuthResponse, error) {
+	if err := config.ValidateAuthCollection(collection); err != nil {
+		return nil, fmt.Errorf("invalid auth collection: %w", err)
+	}
+	if code == "" {
+		return nil, fmt.Errorf("authorization code is required")
+	}
+
+	authData := OAuth2Request{
+		Provider:     provider.Name,
+		Code:         code,
+		CodeVerifier: provider.CodeVerifier,
+		RedirectURL:  redirectURL,
+	}
+
+	endpoint := fmt.Sprintf("collections/%s/auth-with-oauth2", collection)
+
+	utils.PrintDebug(fmt.Sprintf("Authenticating with OAuth2 provider: %s", provider.Name))
+
+	resp, err := c.makeRequest("POST", endpoint, authData)
+	if mfaErr := mfaRequired(resp); mfaErr != nil {
+		return nil, mfaErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("OAuth2 authentication failed: %w", err)
+	}
+
+	var authResp AuthResponse
+	if err := json.Unmarshal(resp.Body(), &authResp); err != nil {
+		return nil, fmt.Errorf("failed to parse authentication response: %w", err)
+	}
+
+	c.setAuth(authResp.Token, authResp.Record, TokenExpiry(authResp.Token))
+
+	utils.PrintDebug("OAuth2 authentication successful")
+
+	return &authResp, nil
+}
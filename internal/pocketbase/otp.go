@@ -0,0 +1,130 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/go-resty/resty/v2"
+	"flint-cli/internal/config"
+	"flint-cli/internal/utils"
+)
+
+// MFARequiredError is returned when the first authentication factor succeeded
+// but PocketBase requires a second one. Pass MFAID to the second auth call.
+type MFARequiredError struct {
+	MFAID string
+}
+
+// Error implements the error interface
+func (e *MFARequiredError) Error() string {
+	return "multi-factor authentication required"
+}
+
+// OTPRequest represents a request-otp request
+type OTPRequest struct {
+	Email string `json:"email"`
+}
+
+// OTPAuthRequest represents an auth-with-otp request
+type OTPAuthRequest struct {
+	OTPID    string `json:"otpId"`
+	Password string `json:"password"`
+	MFAID    string `json:"mfaId,omitempty"`
+}
+
+// RequestOTP asks PocketBase to email a one-time password and returns its OTP ID
+func (c *Client) RequestOTP(collection, email string) (string, error) {
+	if err := config.ValidateAuthCollection(collection); err != nil {
+		return "", fmt.Errorf("invalid auth collection: %w", err)
+	}
+	if email == "" {
+		return "", fmt.Errorf("email is required")
+	}
+
+	endpoint := fmt.Sprintf("collections/%s/request-otp", collection)
+
+	utils.PrintDebug(fmt.Sprintf("Requesting OTP for collection: %s", collection))
+
+	resp, err := c.makeRequest("POST", endpoint, OTPRequest{Email: email})
+	if err != nil {
+		return "", fmt.Errorf("failed to request OTP: %w", err)
+	}
+
+	var result struct {
+		OTPID string `json:"otpId"`
+	}
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return "", fmt.Errorf("failed to parse OTP response: %w", err)
+	}
+	if result.OTPID == "" {
+		return "", fmt.Errorf("server did not return an OTP ID (is OTP enabled for '%s'?)", collection)
+	}
+
+	return result.OTPID, nil
+}
+
+// AuthenticateWithOTP completes a login with a one-time password. mfaID is
+// set when the OTP is the second factor of a multi-factor login.
+func (c *Client) AuthenticateWithOTP(collection, otpID, code, mfaID string) (*AuthResponse, error) {
+	if err := config.ValidateAuthCollection(collection); err != nil {
+		return nil, fmt.Errorf("invalid auth collection: %w", err)
+	}
+	if otpID == "" {
+		return nil, fmt.Errorf("OTP ID is required")
+	}
+	if code == "" {
+		return nil, fmt.Errorf("OTP code is required")
+	}
+
+	endpoint := fmt.Sprintf("collections/%s/auth-with-otp", collection)
+	if mfaID != "" {
+		// PocketBase reads mfaId from the query string; it is sent in the body as well
+		endpoint += "?mfaId=" + url.QueryEscape(mfaID)
+	}
+
+	utils.PrintDebug(fmt.Sprintf("Authenticating with OTP for collection: %s", collection))
+
+	resp, err := c.makeRequest("POST", endpoint, OTPAuthRequest{
+		OTPID:    otpID,
+		Password: code,
+		MFAID:    mfaID,
+	})
+	if mfaErr := mfaRequired(resp); mfaErr != nil {
+		return nil, mfaErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("OTP authentication failed: %w", err)
+	}
+
+	var authResp AuthResponse
+	if err := json.Unmarshal(resp.Body(), &authResp); err != nil {
+		return nil, fmt.Errorf("failed to parse authentication response: %w", err)
+	}
+
+	c.setAuth(authResp.Token, authResp.Record, TokenExpiry(authResp.Token))
+
+	utils.PrintDebug("OTP authentication successful")
+
+	return &authResp, nil
+}
+
+// mfaRequired returns a *MFARequiredError if an auth response asks for a second factor
+func mfaRequired(resp *resty.Response) error {
+	if resp == nil {
+		return nil
+	}
+
+	var result struct {
+		Token string `json:"token"`
+		MFAID string `json:"mfaId"`
+	}
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return nil
+	}
+	if result.MFAID != "" && result.Token == "" {
+		return &MFARequiredError{MFAID: result.MFAID}
+	}
+
+	return nil
+}
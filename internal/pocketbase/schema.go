@@ -0,0 +1,457 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Field's own JSON keys. PocketBase 0.23+ puts a field's type options next to
+// these instead of under "options".
+var fieldKeys = map[string]bool{
+	"id": true, "name": true, "type": true, "system": true, "required": true,
+	"presentable": true, "unique": true, "options": true,
+}
+
+// Record IDs accepted for relations when the target's ID format is unknown
+var defaultIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Date formats accepted for date fields
+var dateLayouts = []string{
+	"2006-01-02 15:04:05.000Z",
+	"2006-01-02 15:04:05Z",
+	"2006-01-02 15:04:05",
+	time.RFC3339Nano,
+	"2006-01-02",
+}
+
+// FieldProblem is one way a value does not match its field definition
+type FieldProblem struct {
+	Field   string
+	Message string
+}
+
+// SchemaError lists every field of a record that does not match the collection schema
+type SchemaError struct {
+	Collection string
+	Problems   []FieldProblem
+}
+
+// Error implements the error interface
+func (e *SchemaError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		lines[i] = fmt.Sprintf("%s: %s", problem.Field, problem.Message)
+	}
+	return fmt.Sprintf("record does not match the %s schema:\n  - %s", e.Collection, strings.Join(lines, "\n  - "))
+}
+
+// UnmarshalJSON reads a field in either the pre-0.23 layout (type options
+// under "options") or the 0.23+ layout (type options inline)
+func (f *Field) UnmarshalJSON(data []byte) error {
+	type plainField Field
+	var decoded plainField
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*f = Field(decoded)
+	if f.Options != nil {
+		return nil
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for key, value := range raw {
+		if fieldKeys[key] {
+			continue
+		}
+		if f.Options == nil {
+			f.Options = make(map[string]interface{})
+		}
+		f.Options[key] = value
+	}
+	return nil
+}
+
+// SchemaFields returns the collection's fields, from "schema" (PocketBase
+// before 0.23) or "fields"
+func (c *Collection) SchemaFields() []Field {
+	if len(c.Schema) > 0 {
+		return c.Schema
+	}
+	return c.Fields
+}
+
+// FindCollection returns the collection with the given name or ID, or nil
+func FindCollection(collections []Collection, nameOrID string) *Collection {
+	for i := range collections {
+		if collections[i].Name == nameOrID || collections[i].ID == nameOrID {
+			return &collections[i]
+		}
+	}
+	return nil
+}
+
+// ValidateRecord checks record data against the collection schema before it
+// is sent: types, required fields, select values, relation IDs, min/max and
+// patterns. With partial (updates), required fields are only checked when
+// included. collections is used to look up the ID format of relation targets.
+// It returns a *SchemaError listing every problem, or nil.
+func (c *Collection) ValidateRecord(data map[string]interface{}, partial bool, collections []Collection) error {
+	fields := make(map[string]Field)
+	var problems []FieldProblem
+
+	for _, field := range c.SchemaFields() {
+		fields[field.Name] = field
+		if !field.Required || generatedField(field) {
+			continue
+		}
+
+		value, present := data[field.Name]
+		switch {
+		case present && isBlank(value) && field.Type == "bool":
+			problems = append(problems, FieldProblem{field.Name, "is required and must be true"})
+		case present && isBlank(value):
+			problems = append(problems, FieldProblem{field.Name, "is required and cannot be blank"})
+		case !present && !partial && !hasModifier(data, field.Name):
+			problems = append(problems, FieldProblem{field.Name, "is required"})
+		}
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name, modifier := splitModifier(key)
+		field, ok := fields[name]
+		if !ok || isBlank(data[key]) {
+			continue
+		}
+		for _, message := range checkFieldValue(field, data[key], modifier, collections) {
+			problems = append(problems, FieldProblem{key, message})
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return &SchemaError{Collection: c.Name, Problems: problems}
+}
+
+// checkFieldValue returns the problems with a non-blank value for a field.
+// Modifier keys ("tags+", "count-") add or remove values, so select and
+// relation limits are not applied to them.
+func checkFieldValue(field Field, value interface{}, modifier bool, collections []Collection) []string {
+	switch field.Type {
+	case "text", "editor", "password":
+		text, ok := scalarString(value)
+		if !ok {
+			return []string{"must be text"}
+		}
+		return checkText(field, text)
+
+	case "email":
+		text, ok := value.(string)
+		if !ok || !strings.Contains(text, "@") || strings.ContainsAny(text, " \t\n") {
+			return []string{"must be an email address"}
+		}
+
+	case "url":
+		text, ok := value.(string)
+		if !ok {
+			return []string{"must be a URL"}
+		}
+		if parsed, err := url.Parse(text); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return []string{"must be a URL"}
+		}
+
+	case "number":
+		number, ok := toNumber(value)
+		if !ok {
+			return []string{"must be a number"}
+		}
+		if modifier {
+			return nil
+		}
+		return checkNumber(field, number)
+
+	case "bool":
+		if _, ok := toBool(value); !ok {
+			return []string{"must be true or false"}
+		}
+
+	case "date":
+		text, ok := value.(string)
+		if !ok || !isDate(text) {
+			return []string{"must be a date (e.g. 2025-01-15 10:30:00.000Z)"}
+		}
+
+	case "select":
+		values, ok := stringList(value)
+		if !ok {
+			return []string{"must be a value or a list of values"}
+		}
+		return checkSelect(field, values, modifier)
+
+	case "relation":
+		ids, ok := stringList(value)
+		if !ok {
+			return []string{"must be a record ID or a list of record IDs"}
+		}
+		return checkRelation(field, ids, modifier, collections)
+	}
+
+	return nil
+}
+
+// checkText applies the min/max length and pattern options of a text field
+func checkText(field Field, text string) []string {
+	var problems []string
+	length := utf8.RuneCountInString(text)
+
+	// A limit of 0 means none for text fields
+	if min, ok := optionFloat(field, "min"); ok && min > 0 && float64(length) < min {
+		problems = append(problems, fmt.Sprintf("must be at least %v characters", min))
+	}
+	if max, ok := optionFloat(field, "max"); ok && max > 0 && float64(length) > max {
+		problems = append(problems, fmt.Sprintf("must be at most %v characters", max))
+	}
+	if pattern := optionString(field, "pattern"); pattern != "" {
+		if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(text) {
+			problems = append(problems, fmt.Sprintf("must match the pattern %s", pattern))
+		}
+	}
+	return problems
+}
+
+// checkNumber applies the min/max and integer options of a number field
+func checkNumber(field Field, number float64) []string {
+	var problems []string
+	if min, ok := optionFloat(field, "min"); ok && number < min {
+		problems = append(problems, fmt.Sprintf("must be at least %v", min))
+	}
+	if max, ok := optionFloat(field, "max"); ok && number > max {
+		problems = append(problems, fmt.Sprintf("must be at most %v", max))
+	}
+	if (optionBool(field, "onlyInt") || optionBool(field, "noDecimal")) && number != float64(int64(number)) {
+		problems = append(problems, "must be a whole number")
+	}
+	return problems
+}
+
+// checkSelect checks select values against the allowed values and maxSelect
+func checkSelect(field Field, values []string, modifier bool) []string {
+	var problems []string
+
+	allowed := optionStrings(field, "values")
+	if len(allowed) > 0 {
+		for _, value := range values {
+			if !containsString(allowed, value) {
+				problems = append(problems, fmt.Sprintf("'%s' is not one of: %s", value, strings.Join(allowed, ", ")))
+			}
+		}
+	}
+
+	// A select field holds one value unless maxSelect allows more
+	maxSelect := 1.0
+	if max, ok := optionFloat(field, "maxSelect"); ok && max > 1 {
+		maxSelect = max
+	}
+	if !modifier && float64(len(values)) > maxSelect {
+		problems = append(problems, fmt.Sprintf("accepts at most %v value(s), got %d", maxSelect, len(values)))
+	}
+	return problems
+}
+
+// checkRelation checks relation IDs against the target collection's ID format and maxSelect
+func checkRelation(field Field, ids []string, modifier bool, collections []Collection) []string {
+	var problems []string
+
+	targetName := "record"
+	check := func(id string) bool { return defaultIDPattern.MatchString(id) }
+	if target := FindCollection(collections, optionString(field, "collectionId")); target != nil {
+		targetName = target.Name
+		for _, targetField := range target.SchemaFields() {
+			if targetField.Name == "id" {
+				idField := targetField
+				check = func(id string) bool { return len(checkText(idField, id)) == 0 }
+			}
+		}
+	}
+
+	for _, id := range ids {
+		if !check(id) {
+			problems = append(problems, fmt.Sprintf("'%s' is not a valid %s ID", id, targetName))
+		}
+	}
+
+	// Without maxSelect (before PocketBase 0.23) a relation is unlimited; 0 or 1 means single
+	if max, ok := optionFloat(field, "maxSelect"); ok && !modifier {
+		if max < 1 {
+			max = 1
+		}
+		if float64(len(ids)) > max {
+			problems = append(problems, fmt.Sprintf("accepts at most %v record(s), got %d", max, len(ids)))
+		}
+	}
+	return problems
+}
+
+// generatedField reports whether the server fills in a field itself, so it is never required from the client
+func generatedField(field Field) bool {
+	return field.Type == "autodate" || optionString(field, "autogeneratePattern") != ""
+}
+
+// splitModifier splits a PocketBase field modifier key ("tags+", "+tags",
+// "tags-") into the field name and whether it had a modifier
+func splitModifier(key string) (string, bool) {
+	switch {
+	case strings.HasSuffix(key, "+"), strings.HasSuffix(key, "-"):
+		return key[:len(key)-1], true
+	case strings.HasPrefix(key, "+"):
+		return key[1:], true
+	}
+	return key, false
+}
+
+// hasModifier reports whether data changes a field through a modifier key
+func hasModifier(data map[string]interface{}, name string) bool {
+	for _, key := range []string{name + "+", "+" + name, name + "-"} {
+		if _, ok := data[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isBlank reports whether a value counts as empty for a required field
+func isBlank(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// scalarString returns a string, number or boolean as text
+func scalarString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+// toNumber converts a JSON number or numeric string, as PocketBase does
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return number, err == nil
+	}
+	return 0, false
+}
+
+// toBool converts a JSON boolean, 0/1 or a boolean string, as PocketBase does
+func toBool(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case float64:
+		return v != 0, v == 0 || v == 1
+	case string:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+		return parsed, err == nil
+	}
+	return false, false
+}
+
+// isDate reports whether text is a date PocketBase accepts
+func isDate(text string) bool {
+	for _, layout := range dateLayouts {
+		if _, err := time.Parse(layout, text); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// stringList returns a string or a list of strings as a list
+func stringList(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, true
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			text, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			list = append(list, text)
+		}
+		return list, true
+	}
+	return nil, false
+}
+
+// optionFloat returns a numeric field option; missing and null options are not set
+func optionFloat(field Field, name string) (float64, bool) {
+	number, ok := field.Options[name].(float64)
+	return number, ok
+}
+
+// optionString returns a string field option, or ""
+func optionString(field Field, name string) string {
+	text, _ := field.Options[name].(string)
+	return text
+}
+
+// optionBool returns a boolean field option, false when not set
+func optionBool(field Field, name string) bool {
+	flag, _ := field.Options[name].(bool)
+	return flag
+}
+
+// optionStrings returns a list-of-strings field option
+func optionStrings(field Field, name string) []string {
+	list, _ := stringList(field.Options[name])
+	return list
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
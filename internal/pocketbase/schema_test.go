@@ -0,0 +1,128 @@
+package pocketbase
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// testCollections are a 0.23+ collection with inline field options and a
+// pre-0.23 collection with options nested under "options"
+const testCollections = `[
+  {"id": "pbc_loc", "name": "locations", "type": "base", "fields": [
+    {"name": "id", "type": "text", "system": true, "required": true, "primaryKey": true,
+     "autogeneratePattern": "[a-z0-9]{15}", "pattern": "^[a-z0-9]+$", "min": 15, "max": 15},
+    {"name": "name", "type": "text", "required": true, "min": 2, "max": 20, "pattern": ""},
+    {"name": "code", "type": "text", "required": true, "min": 0, "max": 0, "pattern": "^[a-z0-9]+$"},
+    {"name": "type", "type": "select", "required": true, "maxSelect": 1, "values": ["site", "room", "building"]},
+    {"name": "parent_id", "type": "relation", "collectionId": "pbc_loc", "maxSelect": 1},
+    {"name": "capacity", "type": "number", "min": 0, "max": 500, "onlyInt": true},
+    {"name": "active", "type": "bool"},
+    {"name": "opened", "type": "date"},
+    {"name": "contact", "type": "email"},
+    {"name": "site", "type": "url"},
+    {"name": "created", "type": "autodate", "onCreate": true}
+  ]},
+  {"id": "pbc_thg", "name": "things", "type": "base", "schema": [
+    {"name": "name", "type": "text", "required": true, "options": {"min": null, "max": null, "pattern": ""}},
+    {"name": "tags", "type": "select", "options": {"maxSelect": 2, "values": ["a", "b", "c"]}},
+    {"name": "location_id", "type": "relation", "required": true, "options": {"collectionId": "pbc_loc", "maxSelect": null}},
+    {"name": "enabled", "type": "bool", "required": true}
+  ]}
+]`
+
+func TestValidateRecord(t *testing.T) {
+	var collections []Collection
+	if err := json.Unmarshal([]byte(testCollections), &collections); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	locations := FindCollection(collections, "locations")
+	things := FindCollection(collections, "pbc_thg")
+	if locations == nil || things == nil {
+		t.Fatal("test collections not found")
+	}
+
+	validLocation := `{"name": "Lab", "code": "lab1", "type": "site"}`
+
+	tests := []struct {
+		name       string
+		collection *Collection
+		data       string
+		partial    bool
+		want       []FieldProblem
+	}{
+		{name: "valid create", collection: locations, data: validLocation},
+		{name: "optional fields", collection: locations,
+			data: `{"name": "Lab", "code": "lab1", "type": "room", "parent_id": "abcdefghij12345", "capacity": 10,
+				"active": "true", "opened": "2025-01-15", "contact": "ops@example.com", "site": "https://example.com"}`},
+		{name: "missing required fields", collection: locations, data: `{"name": "Lab"}`,
+			want: []FieldProblem{{"code", "is required"}, {"type", "is required"}}},
+		{name: "blank required field", collection: locations, data: `{"name": " ", "code": "lab1", "type": "site"}`,
+			want: []FieldProblem{{"name", "is required and cannot be blank"}}},
+		{name: "partial update skips missing required fields", collection: locations, data: `{"capacity": 20}`, partial: true},
+		{name: "partial update still checks blank required fields", collection: locations, data: `{"code": ""}`, partial: true,
+			want: []FieldProblem{{"code", "is required and cannot be blank"}}},
+		{name: "text length and pattern", collection: locations, data: `{"name": "L", "code": "Lab 1", "type": "site"}`,
+			want: []FieldProblem{{"code", "must match the pattern ^[a-z0-9]+$"}, {"name", "must be at least 2 characters"}}},
+		{name: "select value", collection: locations, data: `{"name": "Lab", "code": "lab1", "type": "campus"}`,
+			want: []FieldProblem{{"type", "'campus' is not one of: site, room, building"}}},
+		{name: "single select given a list", collection: locations, data: `{"name": "Lab", "code": "lab1", "type": ["site", "room"]}`,
+			want: []FieldProblem{{"type", "accepts at most 1 value(s), got 2"}}},
+		{name: "number limits", collection: locations, data: `{"capacity": 500.5}`, partial: true,
+			want: []FieldProblem{{"capacity", "must be at most 500"}, {"capacity", "must be a whole number"}}},
+		{name: "number type", collection: locations, data: `{"capacity": "many"}`, partial: true,
+			want: []FieldProblem{{"capacity", "must be a number"}}},
+		{name: "modifier skips limits", collection: locations, data: `{"capacity+": 1000}`, partial: true},
+		{name: "bool, date, email and url", collection: locations,
+			data: `{"active": "maybe", "opened": "yesterday", "contact": "ops", "site": "example.com"}`, partial: true,
+			want: []FieldProblem{
+				{"active", "must be true or false"},
+				{"contact", "must be an email address"},
+				{"opened", "must be a date (e.g. 2025-01-15 10:30:00.000Z)"},
+				{"site", "must be a URL"},
+			}},
+		{name: "relation uses the target id format", collection: locations, data: `{"parent_id": "short"}`, partial: true,
+			want: []FieldProblem{{"parent_id", "'short' is not a valid locations ID"}}},
+		{name: "unknown fields ignored", collection: locations, data: `{"nickname": 5}`, partial: true},
+
+		{name: "legacy schema valid", collection: things,
+			data: `{"name": "Sensor", "location_id": ["abcdefghij12345"], "tags": ["a", "b"], "enabled": true}`},
+		{name: "legacy required bool", collection: things,
+			data: `{"name": "Sensor", "location_id": "abcdefghij12345", "enabled": false}`,
+			want: []FieldProblem{{"enabled", "is required and must be true"}}},
+		{name: "legacy select limit", collection: things,
+			data: `{"tags": ["a", "b", "d"]}`, partial: true,
+			want: []FieldProblem{{"tags", "'d' is not one of: a, b, c"}, {"tags", "accepts at most 2 value(s), got 3"}}},
+		{name: "required field set through a modifier", collection: things,
+			data: `{"name": "Sensor", "location_id+": "abcdefghij12345", "enabled": true}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.data), &data); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+
+			err := tt.collection.ValidateRecord(data, tt.partial, collections)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("ValidateRecord: %v", err)
+				}
+				return
+			}
+
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("ValidateRecord error = %v, want a *SchemaError", err)
+			}
+			if schemaErr.Collection != tt.collection.Name {
+				t.Errorf("Collection = %q, want %q", schemaErr.Collection, tt.collection.Name)
+			}
+			if !reflect.DeepEqual(schemaErr.Problems, tt.want) {
+				t.Errorf("Problems = %v, want %v", schemaErr.Problems, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package pocketbase
+
+import (
+	"fmt"
+)
+
+// MaxPerPage is the largest page size PocketBase accepts
+const MaxPerPage = 500
+
+// StreamOptions controls how StreamRecords walks the pages of a list query
+type StreamOptions struct {
+	Max         int // Stop after this many records (0 = no limit)
+	Concurrency int // Pages fetched in parallel (default 1)
+}
+
+// pageResult is the outcome of fetching one page
+type pageResult struct {
+	page int
+	list *RecordsList
+	err  error
+}
+
+// StreamRecords walks the pages of a list query from page 1 and calls fn for
+// every record, in order. At most Concurrency pages are held in memory at a
+// time, so whole collections can be exported. Pages are requested ahead of
+// the one being delivered; with SkipTotal the end is detected by a short page.
+// It returns the number of records passed to fn.
+func (c *Client) StreamRecords(collection string, options ListOptions, stream StreamOptions,
+	fn func(record map[string]interface{}) error) (int, error) {
+	perPage := options.PerPage
+	if perPage <= 0 || perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+	concurrency := stream.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	// The last page is known up front when --max bounds the result, otherwise
+	// from the first response that reports a total
+	lastPage := 0
+	if stream.Max > 0 {
+		lastPage = (stream.Max + perPage - 1) / perPage
+	}
+
+	fetch := func(page int) <-chan pageResult {
+		// Buffered, so abandoned fetches never block
+		result := make(chan pageResult, 1)
+		go func() {
+			pageOptions := options
+			pageOptions.Page = page
+			pageOptions.PerPage = perPage
+			list, err := c.ListRecords(collection, &pageOptions)
+			result <- pageResult{page: page, list: list, err: err}
+		}()
+		return result
+	}
+
+	var pending []<-chan pageResult
+	nextPage := 1
+	count := 0
+
+	for {
+		for len(pending) < concurrency && (lastPage == 0 || nextPage <= lastPage) {
+			pending = append(pending, fetch(nextPage))
+			nextPage++
+		}
+		if len(pending) == 0 {
+			return count, nil
+		}
+
+		result := <-pending[0]
+		pending = pending[1:]
+		if result.err != nil {
+			return count, fmt.Errorf("page %d: %w", result.page, result.err)
+		}
+
+		list := result.list
+		if list.TotalPages > 0 && (lastPage == 0 || list.TotalPages < lastPage) {
+			lastPage = list.TotalPages
+		}
+
+		for _, record := range list.Items {
+			if err := fn(record); err != nil {
+				return count, err
+			}
+			count++
+			if stream.Max > 0 && count >= stream.Max {
+				return count, nil
+			}
+		}
+
+		// A short page is the last one; anything requested after it is empty
+		if len(list.Items) < perPage || result.page == lastPage {
+			return count, nil
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package pocketbase
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"flint-cli/internal/config"
+	"flint-cli/internal/utils"
+)
+
+// DefaultTimeout is the request timeout used when a context does not set one
+const DefaultTimeout = 30 * time.Second
+
+// NewClientWithConfig creates a PocketBase client that applies the transport
+// settings of a context: CA bundle, client certificate, proxy, timeout and headers
+func NewClientWithConfig(pbConfig *config.PocketBaseConfig) (*Client, error) {
+	client := NewClient(pbConfig.URL)
+	if err := client.configureTransport(pbConfig); err != nil {
+		return client, err
+	}
+	return client, nil
+}
+
+// configureTransport applies the transport settings to the HTTP client
+func (c *Client) configureTransport(pbConfig *config.PocketBaseConfig) error {
+	timeout, err := RequestTimeout(pbConfig)
+	if err != nil {
+		return err
+	}
+	c.httpClient.SetTimeout(timeout)
+
+	tlsConfig, err := buildTLSConfig(pbConfig)
+	if err != nil {
+		return err
+	}
+	if tlsConfig != nil {
+		c.httpClient.SetTLSClientConfig(tlsConfig)
+	}
+
+	if pbConfig.ProxyURL != "" {
+		if err := ValidateProxyURL(pbConfig.ProxyURL); err != nil {
+			return err
+		}
+		utils.PrintDebug(fmt.Sprintf("Using proxy %s", RedactURL(pbConfig.ProxyURL)))
+		c.httpClient.SetProxy(pbConfig.ProxyURL)
+	}
+
+	for key, value := range pbConfig.Headers {
+		c.httpClient.SetHeader(key, value)
+	}
+
+	return nil
+}
+
+// RequestTimeout returns the configured request timeout, or DefaultTimeout
+func RequestTimeout(pbConfig *config.PocketBaseConfig) (time.Duration, error) {
+	if pbConfig.Timeout == "" {
+		return DefaultTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(pbConfig.Timeout)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid PocketBase timeout '%s': use a positive duration such as 45s or 2m", pbConfig.Timeout)
+	}
+
+	return timeout, nil
+}
+
+// ValidateProxyURL checks that a proxy URL has a supported scheme and a host
+func ValidateProxyURL(proxyURL string) error {
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %w", err)
+	}
+
+	switch parsed.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("invalid proxy URL '%s': scheme must be http, https or socks5", RedactURL(proxyURL))
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("invalid proxy URL '%s': missing host", RedactURL(proxyURL))
+	}
+
+	return nil
+}
+
+// buildTLSConfig returns the TLS settings of a context, or nil to keep the defaults
+func buildTLSConfig(pbConfig *config.PocketBaseConfig) (*tls.Config, error) {
+	if pbConfig.TLSCAFile == "" && pbConfig.TLSCertFile == "" && pbConfig.TLSKeyFile == "" &&
+		!pbConfig.TLSInsecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if pbConfig.TLSCAFile != "" {
+		data, err := os.ReadFile(pbConfig.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read PocketBase CA bundle: %w", err)
+		}
+
+		// The private CA is trusted in addition to the system roots
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in PocketBase CA bundle %s", pbConfig.TLSCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if pbConfig.TLSCertFile != "" || pbConfig.TLSKeyFile != "" {
+		if pbConfig.TLSCertFile == "" || pbConfig.TLSKeyFile == "" {
+			return nil, fmt.Errorf("both a client certificate and key are required for PocketBase mutual TLS")
+		}
+		cert, err := tls.LoadX509KeyPair(pbConfig.TLSCertFile, pbConfig.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load PocketBase client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if pbConfig.TLSInsecureSkipVerify {
+		utils.PrintDebug("PocketBase TLS certificate verification disabled")
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	return tlsConfig, nil
+}
+
+// RedactURL hides the password of a URL (e.g. a proxy with credentials) for display
+func RedactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return parsed.Redacted()
+}
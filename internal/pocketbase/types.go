@@ -12,14 +12,16 @@ type Collection struct {
 	Type       string                 `json:"type"` // "base" or "auth"
 	System     bool                   `json:"system"`
 	Schema     []Field                `json:"schema"`
+	Fields     []Field                `json:"fields,omitempty"` // PocketBase 0.23+ name for Schema
 	ListRule   *string                `json:"listRule"`
 	ViewRule   *string                `json:"viewRule"`
 	CreateRule *string                `json:"createRule"`
 	UpdateRule *string                `json:"updateRule"`
 	DeleteRule *string                `json:"deleteRule"`
+	Indexes    []string               `json:"indexes,omitempty"`
 	Options    map[string]interface{} `json:"options"`
-	Created    time.Time              `json:"created"`
-	Updated    time.Time              `json:"updated"`
+	Created    string                 `json:"created,omitempty"` // PocketBase timestamp, not RFC 3339
+	Updated    string                 `json:"updated,omitempty"`
 }
 
 // Field represents a collection field definition
@@ -51,6 +53,8 @@ type ListOptions struct {
 	Filter  string   `json:"filter,omitempty"`
 	Fields  []string `json:"fields,omitempty"`
 	Expand  []string `json:"expand,omitempty"`
+
+	SkipTotal bool `json:"skipTotal,omitempty"` // Skip the total count query (totals are reported as -1)
 }
 
 // Record represents a generic PocketBase record
@@ -390,3 +394,9 @@ type HealthStatus struct {
 		CanBackup bool `json:"canBackup"`
 	} `json:"data"`
 }
+
+// HealthInfo contains the result of a PocketBase health check
+type HealthInfo struct {
+	Latency    time.Duration `json:"latency"`
+	ServerTime *time.Time    `json:"server_time,omitempty"` // From the HTTP Date header
+}
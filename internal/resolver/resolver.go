@@ -44,6 +44,12 @@ func (r *CommandResolver) initializeCommands() {
 		"show",
 		"delete",
 		"organization",
+		"migrate-secrets",
+		"export",
+		"import",
+		"clone",
+		"rename",
+		"doctor",
 	}
 
 	// Collections subcommands (actions - collection names are validated separately)
@@ -53,12 +59,20 @@ func (r *CommandResolver) initializeCommands() {
 		"create",
 		"update",
 		"delete",
+		"import",
+		"describe",
+		"edit",
 	}
 
 	// Auth subcommands
 	r.commands["auth"] = []string{
-		"pb",    // PocketBase auth
-		"nats",  // NATS auth
+		"pb",          // PocketBase auth
+		"nats",        // NATS auth
+		"impersonate", // Superuser impersonation
+		"status",      // Stored credentials
+		"whoami",      // Fresh auth record
+		"logout",      // Remove stored credentials
+		"helper",      // External credential helper
 	}
 
 	// NATS subcommands
@@ -0,0 +1,124 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	tests := []struct {
+		name      string
+		plaintext []byte
+	}{
+		{"text", []byte(`{"pocketbase.auth_token":"eyJhbGciOi"}`)},
+		{"empty", []byte{}},
+		{"binary", []byte{0, 1, 2, 255}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Encrypt(tt.plaintext, []byte("passphrase"))
+			if err != nil {
+				t.Fatalf("Encrypt: %v", err)
+			}
+			if !IsEncrypted(data) {
+				t.Fatalf("IsEncrypted = false for %s", data)
+			}
+			if len(tt.plaintext) > 0 && bytes.Contains(data, tt.plaintext) {
+				t.Fatalf("envelope contains the plaintext")
+			}
+
+			plaintext, err := Decrypt(data, []byte("passphrase"))
+			if err != nil {
+				t.Fatalf("Decrypt: %v", err)
+			}
+			if !bytes.Equal(plaintext, tt.plaintext) {
+				t.Errorf("Decrypt = %q, want %q", plaintext, tt.plaintext)
+			}
+		})
+	}
+}
+
+func TestEncryptEmptyPassphrase(t *testing.T) {
+	if _, err := Encrypt([]byte("secret"), nil); err == nil {
+		t.Fatal("Encrypt with an empty passphrase succeeded")
+	}
+}
+
+func TestDecryptRejects(t *testing.T) {
+	data, err := Encrypt([]byte("secret"), []byte("passphrase"))
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	// modify decodes the envelope, changes it and encodes it again
+	modify := func(change func(env *envelope)) []byte {
+		var env envelope
+		if err := json.Unmarshal(data, &env); err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+		change(&env)
+		out, err := json.Marshal(env)
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+		return out
+	}
+
+	// flip changes the first byte of a base64 field
+	flip := func(field string) string {
+		raw, err := base64.StdEncoding.DecodeString(field)
+		if err != nil {
+			t.Fatalf("DecodeString: %v", err)
+		}
+		raw[0] ^= 0xff
+		return base64.StdEncoding.EncodeToString(raw)
+	}
+
+	tests := []struct {
+		name       string
+		data       []byte
+		passphrase string
+	}{
+		{"wrong passphrase", data, "other"},
+		{"tampered ciphertext", modify(func(env *envelope) { env.Data = flip(env.Data) }), "passphrase"},
+		{"tampered nonce", modify(func(env *envelope) { env.Nonce = flip(env.Nonce) }), "passphrase"},
+		{"tampered salt", modify(func(env *envelope) { env.Salt = flip(env.Salt) }), "passphrase"},
+		{"truncated nonce", modify(func(env *envelope) { env.Nonce = base64.StdEncoding.EncodeToString([]byte("short")) }), "passphrase"},
+		{"unknown version", modify(func(env *envelope) { env.Version = 2 }), "passphrase"},
+		{"unknown kdf", modify(func(env *envelope) { env.KDF = "pbkdf2" }), "passphrase"},
+		{"invalid base64", modify(func(env *envelope) { env.Data = "%%%" }), "passphrase"},
+		{"not an envelope", []byte("not json"), "passphrase"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if plaintext, err := Decrypt(tt.data, []byte(tt.passphrase)); err == nil {
+				t.Errorf("Decrypt succeeded with %q", plaintext)
+			}
+		})
+	}
+}
+
+func TestIsEncrypted(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want bool
+	}{
+		{"envelope", `{"version":1,"kdf":"scrypt","salt":"c2FsdA==","nonce":"bm9uY2U=","data":"ZGF0YQ=="}`, true},
+		{"yaml", "name: doc\n", false},
+		{"other json", `{"name":"doc"}`, false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsEncrypted([]byte(tt.data)); got != tt.want {
+				t.Errorf("IsEncrypted = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
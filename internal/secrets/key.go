@@ -0,0 +1,115 @@
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+// Environment variables for non-interactive vault access
+const (
+	EnvVaultPassphrase  = "FLINT_VAULT_PASSPHRASE"
+	EnvVaultKeyFile     = "FLINT_VAULT_KEY_FILE"
+	EnvBundlePassphrase = "FLINT_BUNDLE_PASSPHRASE"
+)
+
+// KeyProvider returns the passphrase used to encrypt and decrypt vaults.
+// confirm is true when a new vault is about to be created.
+type KeyProvider func(confirm bool) ([]byte, error)
+
+// DefaultKeyProvider resolves the vault passphrase from, in order:
+// the FLINT_VAULT_KEY_FILE environment variable, the configured key file,
+// the FLINT_VAULT_PASSPHRASE environment variable, or an interactive prompt.
+// The resolved passphrase is cached for the lifetime of the process.
+func DefaultKeyProvider(keyFile string) KeyProvider {
+	var cached []byte
+
+	return func(confirm bool) ([]byte, error) {
+		if cached != nil {
+			return cached, nil
+		}
+
+		passphrase, err := resolvePassphrase(keyFile, confirm)
+		if err != nil {
+			return nil, err
+		}
+
+		cached = passphrase
+		return cached, nil
+	}
+}
+
+// resolvePassphrase looks up the vault passphrase from the available sources
+func resolvePassphrase(keyFile string, confirm bool) ([]byte, error) {
+	if envFile := os.Getenv(EnvVaultKeyFile); envFile != "" {
+		keyFile = envFile
+	}
+
+	return ResolvePassphrase("Vault passphrase", keyFile, EnvVaultPassphrase, confirm)
+}
+
+// ResolvePassphrase returns a passphrase from a key file, an environment variable,
+// or an interactive prompt, in that order
+func ResolvePassphrase(label, keyFile, envVar string, confirm bool) ([]byte, error) {
+	if keyFile != "" {
+		return ReadKeyFile(keyFile)
+	}
+
+	if passphrase := os.Getenv(envVar); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+
+	if !term.IsTerminal(int(syscall.Stdin)) {
+		return nil, fmt.Errorf("%s required. Set %s for non-interactive use",
+			strings.ToLower(label), envVar)
+	}
+
+	return PromptPassphrase(label, confirm)
+}
+
+// ReadKeyFile reads a key file
+func ReadKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	key := bytes.TrimSpace(data)
+	if len(key) == 0 {
+		return nil, fmt.Errorf("key file '%s' is empty", path)
+	}
+
+	return key, nil
+}
+
+// PromptPassphrase prompts for a passphrase on the terminal (hidden input)
+func PromptPassphrase(label string, confirm bool) ([]byte, error) {
+	fmt.Fprintf(os.Stderr, "%s: ", label)
+	passphrase, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Fprintln(os.Stderr) // New line after hidden input
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase cannot be empty")
+	}
+
+	if confirm {
+		fmt.Fprintf(os.Stderr, "Confirm %s: ", strings.ToLower(label))
+		again, err := term.ReadPassword(int(syscall.Stdin))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		if !bytes.Equal(passphrase, again) {
+			return nil, fmt.Errorf("passphrases do not match")
+		}
+	}
+
+	return passphrase, nil
+}
@@ -0,0 +1,113 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// VaultFileName is the name of the encrypted secrets file in a context directory
+const VaultFileName = "secrets.vault"
+
+// VaultStore keeps context secrets in an encrypted file inside each context directory
+type VaultStore struct {
+	contextDir func(name string) string
+	key        KeyProvider
+}
+
+// NewVaultStore creates a vault-backed secret store.
+// contextDir maps a context name to its directory.
+func NewVaultStore(contextDir func(name string) string, key KeyProvider) *VaultStore {
+	return &VaultStore{
+		contextDir: contextDir,
+		key:        key,
+	}
+}
+
+// Name returns the store identifier
+func (v *VaultStore) Name() string {
+	return "vault"
+}
+
+// VaultPath returns the path to the vault file for a context
+func (v *VaultStore) VaultPath(contextName string) string {
+	return filepath.Join(v.contextDir(contextName), VaultFileName)
+}
+
+// Load decrypts and returns the secrets for a context
+func (v *VaultStore) Load(contextName string) (map[string]string, error) {
+	data, err := os.ReadFile(v.VaultPath(contextName))
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault: %w", err)
+	}
+
+	passphrase, err := v.key(false)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := Decrypt(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlock vault: %w", err)
+	}
+
+	secrets := make(map[string]string)
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse vault contents: %w", err)
+	}
+
+	return secrets, nil
+}
+
+// Save encrypts and writes the secrets for a context
+func (v *VaultStore) Save(contextName string, secrets map[string]string) error {
+	vaultPath := v.VaultPath(contextName)
+
+	// Nothing to protect - remove any stale vault
+	if len(secrets) == 0 {
+		if err := os.Remove(vaultPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove vault: %w", err)
+		}
+		return nil
+	}
+
+	_, statErr := os.Stat(vaultPath)
+	passphrase, err := v.key(os.IsNotExist(statErr))
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("failed to encode secrets: %w", err)
+	}
+
+	data, err := Encrypt(plaintext, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt secrets: %w", err)
+	}
+
+	// Write to a temporary file first so a failed write never corrupts the vault
+	tmpPath := vaultPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write vault: %w", err)
+	}
+	if err := os.Rename(tmpPath, vaultPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write vault: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the vault for a context
+func (v *VaultStore) Delete(contextName string) error {
+	if err := os.Remove(v.VaultPath(contextName)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete vault: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// staticKey returns a key provider that always returns passphrase
+func staticKey(passphrase string) KeyProvider {
+	return func(confirm bool) ([]byte, error) {
+		return []byte(passphrase), nil
+	}
+}
+
+// newTestVault returns a vault store keeping every context in dir
+func newTestVault(dir, passphrase string) *VaultStore {
+	return NewVaultStore(func(name string) string { return filepath.Join(dir, name) }, staticKey(passphrase))
+}
+
+func TestVaultRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		secrets map[string]string
+		want    map[string]string
+	}{
+		{"secrets", map[string]string{"pocketbase.auth_token": "eyJhbGciOi", "nats.password": "hunter2"}, map[string]string{"pocketbase.auth_token": "eyJhbGciOi", "nats.password": "hunter2"}},
+		{"nothing to protect", map[string]string{}, map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.Mkdir(filepath.Join(dir, "doc"), 0700); err != nil {
+				t.Fatal(err)
+			}
+			vault := newTestVault(dir, "passphrase")
+
+			if err := vault.Save("doc", tt.secrets); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+
+			data, err := os.ReadFile(vault.VaultPath("doc"))
+			if len(tt.secrets) == 0 {
+				if !os.IsNotExist(err) {
+					t.Errorf("vault written for no secrets (err %v)", err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("ReadFile: %v", err)
+				}
+				for _, value := range tt.secrets {
+					if bytes.Contains(data, []byte(value)) {
+						t.Errorf("vault contains %q in plaintext", value)
+					}
+				}
+			}
+
+			got, err := vault.Load("doc")
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVaultLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "doc"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := newTestVault(dir, "passphrase").Save("doc", map[string]string{"nats.token": "secret"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		vault *VaultStore
+	}{
+		{"wrong passphrase", newTestVault(dir, "other")},
+		{"key unavailable", NewVaultStore(func(name string) string { return filepath.Join(dir, name) },
+			func(confirm bool) ([]byte, error) { return nil, fmt.Errorf("no passphrase") })},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if secrets, err := tt.vault.Load("doc"); err == nil {
+				t.Errorf("Load succeeded with %v", secrets)
+			}
+		})
+	}
+}
+
+func TestVaultDelete(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "doc"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	vault := newTestVault(dir, "passphrase")
+	if err := vault.Save("doc", map[string]string{"nats.token": "secret"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if err := vault.Delete("doc"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := os.Stat(vault.VaultPath("doc")); !os.IsNotExist(err) {
+		t.Errorf("vault still exists after Delete (err %v)", err)
+	}
+	if err := vault.Delete("doc"); err != nil {
+		t.Errorf("Delete of a missing vault: %v", err)
+	}
+}
@@ -0,0 +1,324 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"flint-cli/internal/config"
+)
+
+// IsRecordFormat reports whether format writes one record per line
+// (ndjson, csv or tsv), which suits piping into other tools
+func IsRecordFormat(format string) bool {
+	switch strings.ToLower(format) {
+	case config.OutputFormatNDJSON, config.OutputFormatCSV, config.OutputFormatTSV:
+		return true
+	}
+	return false
+}
+
+// OutputRecords prints records as ndjson, csv or tsv. For csv and tsv the
+// columns follow fields when given (object fields expand to their dotted
+// keys), otherwise every flattened key with id first and the rest sorted.
+func OutputRecords(w io.Writer, records []map[string]interface{}, format string, fields []string) error {
+	switch strings.ToLower(format) {
+	case config.OutputFormatNDJSON:
+		encoder := newNDJSONEncoder(w)
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+		}
+		return nil
+
+	case config.OutputFormatCSV, config.OutputFormatTSV:
+		flat := make([]map[string]interface{}, len(records))
+		for i, record := range records {
+			flat[i] = FlattenRecord(record)
+		}
+
+		writer := newDelimitedWriter(w, format, fields)
+		writer.columns = RecordColumns(flat, fields)
+		if len(writer.columns) > 0 {
+			if err := writer.writeHeader(); err != nil {
+				return err
+			}
+		}
+		for _, record := range flat {
+			if err := writer.writeFlat(record); err != nil {
+				return err
+			}
+		}
+		return writer.flush()
+
+	default:
+		return fmt.Errorf("unsupported record output format: %s", format)
+	}
+}
+
+// FlattenRecord flattens nested objects, such as expanded relations, into
+// dotted keys (expand.location.name). Arrays are kept as single values.
+func FlattenRecord(record map[string]interface{}) map[string]interface{} {
+	flat := make(map[string]interface{}, len(record))
+	flattenInto(flat, "", record)
+	return flat
+}
+
+// flattenInto adds the fields of value to flat under prefix
+func flattenInto(flat map[string]interface{}, prefix string, value map[string]interface{}) {
+	for key, v := range value {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := v.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenInto(flat, key, nested)
+			continue
+		}
+		flat[key] = v
+	}
+}
+
+// RecordColumns returns the columns for flattened records. Fields are used in
+// the order given; a field naming an object expands to its dotted keys and
+// PocketBase field modifiers (description:excerpt(200)) are dropped.
+func RecordColumns(flatRecords []map[string]interface{}, fields []string) []string {
+	keys := make(map[string]bool)
+	for _, record := range flatRecords {
+		for key := range record {
+			keys[key] = true
+		}
+	}
+
+	var wanted []string
+	for _, field := range fields {
+		field, _, _ = strings.Cut(strings.TrimSpace(field), ":")
+		field = strings.TrimSuffix(field, ".*")
+		if field != "" && field != "*" {
+			wanted = append(wanted, field)
+		}
+	}
+	if len(wanted) == 0 {
+		all := make([]string, 0, len(keys))
+		for key := range keys {
+			all = append(all, key)
+		}
+		return orderColumns(all)
+	}
+
+	var columns []string
+	for _, field := range wanted {
+		if keys[field] {
+			columns = append(columns, field)
+			continue
+		}
+
+		var nested []string
+		for key := range keys {
+			if strings.HasPrefix(key, field+".") {
+				nested = append(nested, key)
+			}
+		}
+		if len(nested) == 0 {
+			// Keep the column so the layout does not depend on the data
+			columns = append(columns, field)
+			continue
+		}
+		sort.Strings(nested)
+		columns = append(columns, nested...)
+	}
+	return columns
+}
+
+// orderColumns sorts column names, keeping id first
+func orderColumns(keys []string) []string {
+	columns := make([]string, 0, len(keys))
+	hasID := false
+	for _, key := range keys {
+		if key == "id" {
+			hasID = true
+			continue
+		}
+		columns = append(columns, key)
+	}
+	sort.Strings(columns)
+
+	if hasID {
+		columns = append([]string{"id"}, columns...)
+	}
+	return columns
+}
+
+// FormatCell formats a value for a csv or tsv cell. Arrays and objects are
+// written as compact JSON.
+func FormatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}, map[string]interface{}, map[string]string:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// newNDJSONEncoder returns an encoder writing one compact JSON document per line
+func newNDJSONEncoder(w io.Writer) *json.Encoder {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return encoder
+}
+
+// delimitedWriter writes records as csv or tsv rows after a header line
+type delimitedWriter struct {
+	csv     *csv.Writer
+	fields  []string
+	order   []string // Collection schema order, for streamed records
+	expand  []string // Expanded relations, for streamed records
+	columns []string
+	header  bool
+}
+
+// newDelimitedWriter creates a csv writer, or a tsv writer for the tsv format
+func newDelimitedWriter(w io.Writer, format string, fields []string) *delimitedWriter {
+	writer := csv.NewWriter(w)
+	if strings.ToLower(format) == config.OutputFormatTSV {
+		writer.Comma = '\t'
+	}
+	return &delimitedWriter{csv: writer, fields: fields}
+}
+
+// write writes a streamed record, flattened as in OutputRecords. The header
+// cannot wait for every record, so with fields the columns follow them and
+// otherwise they are the schema fields, expanded to the dotted keys of the
+// first record, then its other keys and the expanded relations it lacks.
+// A column a later record holds as an object is written as JSON.
+func (d *delimitedWriter) write(record map[string]interface{}) error {
+	flat := FlattenRecord(record)
+	if d.columns == nil {
+		if len(d.fields) > 0 {
+			d.columns = RecordColumns([]map[string]interface{}{flat}, d.fields)
+		} else {
+			d.columns = streamColumns(flat, d.order, d.expand)
+		}
+	}
+
+	for _, column := range d.columns {
+		if _, ok := flat[column]; !ok {
+			if value, ok := lookupPath(record, column); ok {
+				flat[column] = value
+			}
+		}
+	}
+	return d.writeFlat(flat)
+}
+
+// streamColumns returns the columns for a stream from its first flattened
+// record: the fields of order, each expanded to its dotted keys, then the
+// record's other keys sorted (with id first when no order is known), then an
+// expand.<relation> column for each expanded relation the record lacks
+func streamColumns(flat map[string]interface{}, order, expand []string) []string {
+	var columns []string
+	used := make(map[string]bool)
+	add := func(column string) {
+		if !used[column] {
+			columns = append(columns, column)
+			used[column] = true
+		}
+	}
+
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, field := range order {
+		if _, ok := flat[field]; ok {
+			add(field)
+			continue
+		}
+		nested := false
+		for _, key := range keys {
+			if strings.HasPrefix(key, field+".") {
+				add(key)
+				nested = true
+			}
+		}
+		if !nested {
+			// Keep the column so the layout does not depend on the first record
+			add(field)
+		}
+	}
+
+	for _, key := range orderColumns(keys) {
+		add(key)
+	}
+
+	for _, relation := range expand {
+		relation, _, _ = strings.Cut(strings.TrimSpace(relation), ".")
+		if relation == "" {
+			continue
+		}
+		column := "expand." + relation
+		covered := false
+		for _, existing := range columns {
+			if existing == column || strings.HasPrefix(existing, column+".") {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			add(column)
+		}
+	}
+	return columns
+}
+
+// writeHeader writes the header line once
+func (d *delimitedWriter) writeHeader() error {
+	if d.header {
+		return nil
+	}
+	if err := d.csv.Write(d.columns); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	d.header = true
+	return nil
+}
+
+// writeFlat writes a flattened record, preceded by the header on the first call
+func (d *delimitedWriter) writeFlat(flat map[string]interface{}) error {
+	if err := d.writeHeader(); err != nil {
+		return err
+	}
+
+	row := make([]string, len(d.columns))
+	for i, column := range d.columns {
+		row[i] = FormatCell(flat[column])
+	}
+	if err := d.csv.Write(row); err != nil {
+		return fmt.Errorf("failed to write row: %w", err)
+	}
+	return nil
+}
+
+// flush flushes buffered rows to the underlying writer
+func (d *delimitedWriter) flush() error {
+	d.csv.Flush()
+	return d.csv.Error()
+}
@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestFlattenRecord(t *testing.T) {
+	tests := []struct {
+		name   string
+		record map[string]interface{}
+		want   map[string]interface{}
+	}{
+		{
+			name:   "flat",
+			record: map[string]interface{}{"id": "abc", "count": 3.0},
+			want:   map[string]interface{}{"id": "abc", "count": 3.0},
+		},
+		{
+			name: "expanded relation",
+			record: map[string]interface{}{
+				"id":     "abc",
+				"expand": map[string]interface{}{"location": map[string]interface{}{"id": "loc1", "name": "Lab"}},
+			},
+			want: map[string]interface{}{"id": "abc", "expand.location.id": "loc1", "expand.location.name": "Lab"},
+		},
+		{
+			name:   "arrays stay whole",
+			record: map[string]interface{}{"tags": []interface{}{"a", "b"}},
+			want:   map[string]interface{}{"tags": []interface{}{"a", "b"}},
+		},
+		{
+			name:   "empty object kept",
+			record: map[string]interface{}{"meta": map[string]interface{}{}},
+			want:   map[string]interface{}{"meta": map[string]interface{}{}},
+		},
+		{
+			name:   "null",
+			record: map[string]interface{}{"parent_id": nil},
+			want:   map[string]interface{}{"parent_id": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FlattenRecord(tt.record); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FlattenRecord = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordColumns(t *testing.T) {
+	records := []map[string]interface{}{
+		{"name": "Lab", "id": "abc", "code": "lab1", "expand.location.name": "Site", "expand.location.id": "loc1"},
+		{"id": "def", "type": "room"},
+	}
+
+	tests := []struct {
+		name   string
+		fields []string
+		want   []string
+	}{
+		{"all fields, id first", nil, []string{"id", "code", "expand.location.id", "expand.location.name", "name", "type"}},
+		{"fields in given order", []string{"name", "id"}, []string{"name", "id"}},
+		{"object expands to dotted keys", []string{"id", "expand.location"}, []string{"id", "expand.location.id", "expand.location.name"}},
+		{"wildcard suffix", []string{"expand.location.*"}, []string{"expand.location.id", "expand.location.name"}},
+		{"modifier dropped", []string{"id", "name:excerpt(200)"}, []string{"id", "name"}},
+		{"missing field kept", []string{"id", "capacity"}, []string{"id", "capacity"}},
+		{"wildcard only", []string{"*"}, []string{"id", "code", "expand.location.id", "expand.location.name", "name", "type"}},
+		{"spaces trimmed", []string{" id ", " name"}, []string{"id", "name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RecordColumns(records, tt.fields); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RecordColumns = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordStreamDelimited(t *testing.T) {
+	records := []map[string]interface{}{
+		{"id": "a1", "name": "Lab"},
+		{"id": "b2", "name": "Depot", "capacity": 5.0, "meta": map[string]interface{}{"floor": 2.0}},
+	}
+
+	expanded := []map[string]interface{}{
+		{"id": "e1", "location": "l1", "expand": map[string]interface{}{"location": map[string]interface{}{"id": "l1", "name": "Lab"}}},
+		{"id": "e2", "location": "l2", "expand": map[string]interface{}{"location": map[string]interface{}{"id": "l2", "name": "Depot", "code": "dp"}}},
+	}
+	unexpanded := []map[string]interface{}{
+		{"id": "e1", "location": ""},
+		{"id": "e2", "location": "l2", "expand": map[string]interface{}{"location": map[string]interface{}{"id": "l2", "name": "Depot"}}},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		fields  []string
+		order   []string
+		expand  []string
+		records []map[string]interface{}
+		want    string
+	}{
+		{
+			name:    "expanded relation flattened",
+			format:  "csv",
+			order:   []string{"id", "location"},
+			expand:  []string{"location"},
+			records: expanded,
+			want:    "id,location,expand.location.id,expand.location.name\ne1,l1,l1,Lab\ne2,l2,l2,Depot\n",
+		},
+		{
+			name:    "expanded relation missing from the first record",
+			format:  "csv",
+			order:   []string{"id", "location"},
+			expand:  []string{"location.parent"},
+			records: unexpanded,
+			want:    "id,location,expand.location\ne1,,\ne2,l2,\"{\"\"id\"\":\"\"l2\"\",\"\"name\"\":\"\"Depot\"\"}\"\n",
+		},
+		{
+			name:   "field order",
+			format: "csv",
+			order:  []string{"id", "name", "capacity", "meta"},
+			want:   "id,name,capacity,meta\na1,Lab,,\nb2,Depot,5,\"{\"\"floor\"\":2}\"\n",
+		},
+		{
+			name:   "first record without field order",
+			format: "csv",
+			want:   "id,name\na1,Lab\nb2,Depot\n",
+		},
+		{
+			name:   "fields",
+			format: "tsv",
+			fields: []string{"name", "meta.floor"},
+			order:  []string{"id", "name"},
+			want:   "name\tmeta.floor\nLab\t\nDepot\t2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			stream, err := NewRecordStream(&buf, tt.format, tt.fields)
+			if err != nil {
+				t.Fatalf("NewRecordStream: %v", err)
+			}
+			if tt.order != nil {
+				stream.SetFieldOrder(tt.order)
+			}
+			stream.SetExpand(tt.expand)
+			if tt.records == nil {
+				tt.records = records
+			}
+			for _, record := range tt.records {
+				if err := stream.Write(record); err != nil {
+					t.Fatalf("Write: %v", err)
+				}
+			}
+			if err := stream.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
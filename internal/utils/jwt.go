@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// JWTClaims decodes the claims of a JWT. The signature is not verified.
+func JWTClaims(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid JWT format")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT payload: %w", err)
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("invalid JWT claims: %w", err)
+	}
+
+	return claims, nil
+}
+
+// JWTExpiry returns the expiry time encoded in a JWT's "exp" claim.
+// Returns nil if the token has no expiry. The signature is not verified.
+func JWTExpiry(token string) (*time.Time, error) {
+	claims, err := JWTClaims(token)
+	if err != nil {
+		return nil, err
+	}
+
+	exp, _ := claims["exp"].(float64)
+	if exp == 0 {
+		return nil, nil
+	}
+
+	expires := time.Unix(int64(exp), 0)
+	return &expires, nil
+}
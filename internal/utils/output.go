@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
@@ -21,11 +22,55 @@ func OutputData(data interface{}, format string) error {
 		return outputYAML(data)
 	case config.OutputFormatTable:
 		return outputTable(data)
+	case config.OutputFormatNDJSON, config.OutputFormatCSV, config.OutputFormatTSV:
+		records, err := toRecords(data)
+		if err != nil {
+			return err
+		}
+		return OutputRecords(os.Stdout, records, format, nil)
 	default:
 		return fmt.Errorf("unsupported output format: %s", format)
 	}
 }
 
+// toRecords converts data to JSON objects for the one-record-per-line
+// formats: a list becomes one record per element, anything else one record
+func toRecords(data interface{}) ([]map[string]interface{}, error) {
+	if records, ok := data.([]map[string]interface{}); ok {
+		return records, nil
+	}
+	if record, ok := data.(map[string]interface{}); ok {
+		return []map[string]interface{}{record}, nil
+	}
+
+	// Structs and typed slices go through their JSON form
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to convert output: %w", err)
+	}
+
+	switch v := decoded.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{v}, nil
+	case []interface{}:
+		records := make([]map[string]interface{}, 0, len(v))
+		for _, item := range v {
+			if record, ok := item.(map[string]interface{}); ok {
+				records = append(records, record)
+			} else {
+				records = append(records, map[string]interface{}{"value": item})
+			}
+		}
+		return records, nil
+	default:
+		return []map[string]interface{}{{"value": v}}, nil
+	}
+}
+
 // outputJSON prints data in JSON format
 func outputJSON(data interface{}) error {
 	output, err := json.MarshalIndent(data, "", "  ")
@@ -68,43 +113,18 @@ func outputMapSliceTable(data []map[string]interface{}) error {
 		return nil
 	}
 
-	// Extract headers from first item
-	var headers []string
-	for key := range data[0] {
-		headers = append(headers, key)
-	}
-
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader(headers)
-
-	// Add rows
-	for _, item := range data {
-		var row []string
-		for _, header := range headers {
-			value := ""
-			if v, ok := item[header]; ok && v != nil {
-				value = fmt.Sprintf("%v", v)
-			}
-			row = append(row, value)
-		}
-		table.Append(row)
-	}
-
-	table.Render()
-	return nil
+	return RenderTable(os.Stdout, data, TableOptions{MaxWidth: TerminalWidth()})
 }
 
 // outputMapTable outputs a single map as a vertical table
 func outputMapTable(data map[string]interface{}) error {
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetHeader([]string{"Field", "Value"})
+	table.SetAutoWrapText(false)
 
-	for key, value := range data {
-		valueStr := ""
-		if value != nil {
-			valueStr = fmt.Sprintf("%v", value)
-		}
-		table.Append([]string{key, valueStr})
+	now := time.Now()
+	for _, key := range sortedKeys(data) {
+		table.Append([]string{key, TableCell(data, key, now)})
 	}
 
 	table.Render()
@@ -135,6 +155,10 @@ func PrintWarning(message string) {
 
 // PrintSuccess prints a success message with consistent formatting
 func PrintSuccess(message string) {
+	if config.Global.Quiet {
+		return
+	}
+
 	if !config.Global.ColorsEnabled {
 		fmt.Printf("Success: %s\n", message)
 		return
@@ -146,6 +170,10 @@ func PrintSuccess(message string) {
 
 // PrintInfo prints an info message with consistent formatting
 func PrintInfo(message string) {
+	if config.Global.Quiet {
+		return
+	}
+
 	if !config.Global.ColorsEnabled {
 		fmt.Printf("Info: %s\n", message)
 		return
@@ -189,9 +217,14 @@ func FormatDuration(seconds int64) string {
 	if seconds < 3600 {
 		return fmt.Sprintf("%dm %ds", seconds/60, seconds%60)
 	}
-	hours := seconds / 3600
-	minutes := (seconds % 3600) / 60
-	return fmt.Sprintf("%dh %dm", hours, minutes)
+	if seconds < 86400 {
+		hours := seconds / 3600
+		minutes := (seconds % 3600) / 60
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	days := seconds / 86400
+	hours := (seconds % 86400) / 3600
+	return fmt.Sprintf("%dd %dh", days, hours)
 }
 
 // TitleCase converts a string to title case (first letter uppercase)
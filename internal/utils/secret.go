@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// SecretInput describes the non-interactive sources a secret can be read from,
+// typically a --password flag, --password-stdin, --password-file and an
+// environment variable
+type SecretInput struct {
+	Name   string // Flag name used in messages, e.g. "password"
+	Value  string // Value passed directly on the command line
+	Stdin  bool   // Read the secret from standard input
+	File   string // Read the secret from a file
+	EnvVar string // Environment variable consulted when no flag is given
+}
+
+// Explicit reports whether a secret was given through a flag
+func (s SecretInput) Explicit() bool {
+	return s.Value != "" || s.Stdin || s.File != ""
+}
+
+// Validate checks that at most one flag source is used
+func (s SecretInput) Validate() error {
+	sources := 0
+	for _, set := range []bool{s.Value != "", s.Stdin, s.File != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("only one of --%s, --%s-stdin and --%s-file may be used", s.Name, s.Name, s.Name)
+	}
+	return nil
+}
+
+// Resolve returns the secret from the flag, stdin, file or environment variable,
+// in that order. An empty string means no source was set and the caller may prompt.
+func (s SecretInput) Resolve() (string, error) {
+	if err := s.Validate(); err != nil {
+		return "", err
+	}
+
+	switch {
+	case s.Value != "":
+		return s.Value, nil
+	case s.Stdin:
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s from stdin: %w", s.Name, err)
+		}
+		secret := trimLineEnding(string(data))
+		if secret == "" {
+			return "", fmt.Errorf("no %s received on stdin", s.Name)
+		}
+		return secret, nil
+	case s.File != "":
+		data, err := os.ReadFile(s.File)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s file: %w", s.Name, err)
+		}
+		secret := trimLineEnding(string(data))
+		if secret == "" {
+			return "", fmt.Errorf("%s file '%s' is empty", s.Name, s.File)
+		}
+		return secret, nil
+	case s.EnvVar != "":
+		return os.Getenv(s.EnvVar), nil
+	}
+
+	return "", nil
+}
+
+// trimLineEnding removes the trailing newline that echo and most editors add,
+// keeping any other whitespace that may be part of the secret
+func trimLineEnding(s string) string {
+	return strings.TrimRight(s, "\r\n")
+}
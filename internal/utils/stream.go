@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"flint-cli/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// streamTableBatch is how many rows a streamed table buffers before rendering
+const streamTableBatch = 100
+
+// RecordStream writes records one at a time, so large result sets never have
+// to be held in memory. JSON is written as an array, YAML as a sequence and
+// ndjson as one line per record; tables are rendered in batches whose columns
+// are fixed from the field order or defaults and the first batch. csv and tsv
+// records are flattened, with columns from fields when given, otherwise from
+// the field order and the first record.
+type RecordStream struct {
+	out       *bufio.Writer
+	format    string
+	count     int
+	table     TableOptions
+	batch     []map[string]interface{}
+	ndjson    *json.Encoder
+	delimited *delimitedWriter
+}
+
+// NewRecordStream creates a record stream writing to w in the given format
+func NewRecordStream(w io.Writer, format string, fields []string) (*RecordStream, error) {
+	format = strings.ToLower(format)
+	switch format {
+	case "":
+		format = config.OutputFormatJSON
+	case config.OutputFormatJSON, config.OutputFormatYAML, config.OutputFormatTable,
+		config.OutputFormatNDJSON, config.OutputFormatCSV, config.OutputFormatTSV:
+	default:
+		return nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	stream := &RecordStream{out: bufio.NewWriter(w), format: format}
+	switch format {
+	case config.OutputFormatNDJSON:
+		stream.ndjson = newNDJSONEncoder(stream.out)
+	case config.OutputFormatCSV, config.OutputFormatTSV:
+		stream.delimited = newDelimitedWriter(stream.out, format, fields)
+	}
+	return stream, nil
+}
+
+// SetTableOptions sets how table output is rendered
+func (s *RecordStream) SetTableOptions(options TableOptions) {
+	s.table = options
+}
+
+// SetFieldOrder sets the schema field order used for table, csv and tsv
+// columns, if not already known
+func (s *RecordStream) SetFieldOrder(fields []string) {
+	if len(s.table.FieldOrder) == 0 {
+		s.table.FieldOrder = fields
+	}
+	if s.delimited != nil && len(s.delimited.order) == 0 {
+		s.delimited.order = fields
+	}
+}
+
+// SetExpand sets the expanded relations, so csv and tsv have a column for
+// each even when the first record does not include it
+func (s *RecordStream) SetExpand(relations []string) {
+	if s.delimited != nil {
+		s.delimited.expand = relations
+	}
+}
+
+// Write writes a single record to the stream
+func (s *RecordStream) Write(record map[string]interface{}) error {
+	var err error
+	switch s.format {
+	case config.OutputFormatJSON:
+		err = s.writeJSON(record)
+	case config.OutputFormatYAML:
+		err = s.writeYAML(record)
+	case config.OutputFormatTable:
+		s.batch = append(s.batch, record)
+		if len(s.batch) >= streamTableBatch {
+			err = s.renderTable()
+		}
+	case config.OutputFormatNDJSON:
+		if err = s.ndjson.Encode(record); err != nil {
+			err = fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+	case config.OutputFormatCSV, config.OutputFormatTSV:
+		err = s.delimited.write(record)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.count++
+	return nil
+}
+
+// Flush writes buffered records to the output, for streams that must show
+// each record as it arrives. Table rows stay buffered until their batch is full.
+func (s *RecordStream) Flush() error {
+	if s.delimited != nil {
+		if err := s.delimited.flush(); err != nil {
+			return err
+		}
+	}
+	return s.out.Flush()
+}
+
+// Count returns the number of records written so far
+func (s *RecordStream) Count() int {
+	return s.count
+}
+
+// Close finishes the output (closing the JSON array, rendering the last table
+// rows) and flushes it
+func (s *RecordStream) Close() error {
+	switch s.format {
+	case config.OutputFormatJSON:
+		if s.count == 0 {
+			s.out.WriteString("[]\n")
+		} else {
+			s.out.WriteString("\n]\n")
+		}
+	case config.OutputFormatYAML:
+		if s.count == 0 {
+			s.out.WriteString("[]\n")
+		}
+	case config.OutputFormatTable:
+		if s.count == 0 {
+			s.out.WriteString("No data found.\n")
+		}
+		if err := s.renderTable(); err != nil {
+			return err
+		}
+	case config.OutputFormatCSV, config.OutputFormatTSV:
+		if err := s.delimited.flush(); err != nil {
+			return err
+		}
+	}
+
+	return s.out.Flush()
+}
+
+// writeJSON writes a record as the next element of a JSON array
+func (s *RecordStream) writeJSON(record map[string]interface{}) error {
+	data, err := json.MarshalIndent(record, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	if s.count == 0 {
+		s.out.WriteString("[\n  ")
+	} else {
+		s.out.WriteString(",\n  ")
+	}
+	_, err = s.out.Write(data)
+	return err
+}
+
+// writeYAML writes a record as the next item of a YAML sequence
+func (s *RecordStream) writeYAML(record map[string]interface{}) error {
+	data, err := yaml.Marshal([]map[string]interface{}{record})
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	_, err = s.out.Write(data)
+	return err
+}
+
+// renderTable renders the buffered records as a table
+func (s *RecordStream) renderTable() error {
+	if len(s.batch) == 0 {
+		return nil
+	}
+
+	// Later batches keep the columns of the first one
+	if len(s.table.Columns) == 0 {
+		s.table.Columns = StreamTableColumns(s.batch, s.table)
+	}
+	if err := RenderTable(s.out, s.batch, s.table); err != nil {
+		return err
+	}
+
+	s.batch = s.batch[:0]
+	return nil
+}
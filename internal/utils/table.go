@@ -0,0 +1,370 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/olekukonko/tablewriter"
+	"golang.org/x/term"
+)
+
+// TableOptions controls how records are rendered as a table
+type TableOptions struct {
+	Columns    []string // Explicit column selection (--columns), used as given
+	Defaults   []string // Columns shown when not in wide mode (empty = all but system fields)
+	FieldOrder []string // Collection schema order; other fields follow sorted by name
+	Wide       bool     // Show every field, including system fields, without fitting to the terminal
+	MaxWidth   int      // Width to fit the table into (0 = no limit)
+}
+
+// Fields that lead every table, in this order
+var leadingColumns = []string{"id", "name"}
+
+// System fields only shown in wide mode or when selected
+var systemColumns = map[string]bool{
+	"collectionId":   true,
+	"collectionName": true,
+	"expand":         true,
+}
+
+// Fields rendered as relative times ("5m ago")
+var timestampColumns = map[string]bool{
+	"created": true,
+	"updated": true,
+}
+
+// Fields tried, in order, to present an expanded relation
+var presentableFields = []string{"name", "title", "label", "code", "email", "username"}
+
+// Narrowest a column is shrunk to when fitting the terminal
+const minColumnWidth = 6
+
+// TerminalWidth returns the width of the terminal on stdout, COLUMNS when set,
+// or 0 when stdout is not a terminal
+func TerminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// RenderTable writes records as a table with stable, configurable columns
+func RenderTable(w io.Writer, records []map[string]interface{}, options TableOptions) error {
+	columns := TableColumns(records, options)
+	if len(columns) == 0 {
+		fmt.Fprintln(w, "No data found.")
+		return nil
+	}
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = strings.ToUpper(column)
+	}
+
+	now := time.Now()
+	rows := make([][]string, len(records))
+	for i, record := range records {
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			row[j] = TableCell(record, column, now)
+		}
+		rows[i] = row
+	}
+
+	if !options.Wide && options.MaxWidth > 0 {
+		fitColumns(headers, rows, options.MaxWidth)
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetHeader(headers)
+	table.AppendBulk(rows)
+	table.Render()
+	return nil
+}
+
+// TableColumns returns the columns to render: the selected columns, or the
+// defaults present in the records, or every field in schema order. id and
+// name lead unless columns were selected explicitly.
+func TableColumns(records []map[string]interface{}, options TableOptions) []string {
+	return tableColumns(records, nil, options)
+}
+
+// StreamTableColumns returns the columns for a table rendered in batches. They
+// cannot change after the first batch, so the fields of the field order, or
+// the defaults when it is unknown, count as present even when the first batch
+// leaves them empty.
+func StreamTableColumns(records []map[string]interface{}, options TableOptions) []string {
+	seed := options.FieldOrder
+	if len(seed) == 0 {
+		seed = options.Defaults
+	}
+	return tableColumns(records, seed, options)
+}
+
+// tableColumns selects the columns from the fields present in the records or seed
+func tableColumns(records []map[string]interface{}, seed []string, options TableOptions) []string {
+	if len(options.Columns) > 0 {
+		return options.Columns
+	}
+
+	present := make(map[string]bool)
+	var extra []string
+	for _, field := range seed {
+		if !present[field] {
+			present[field] = true
+			extra = append(extra, field)
+		}
+	}
+	for _, record := range records {
+		for key := range record {
+			if !present[key] {
+				present[key] = true
+				extra = append(extra, key)
+			}
+		}
+	}
+
+	// Schema order first, then fields the server did not report, sorted
+	var ordered []string
+	seen := make(map[string]bool)
+	for _, field := range options.FieldOrder {
+		if present[field] && !seen[field] {
+			ordered = append(ordered, field)
+			seen[field] = true
+		}
+	}
+	sort.Strings(extra)
+	for _, field := range extra {
+		if !seen[field] {
+			ordered = append(ordered, field)
+			seen[field] = true
+		}
+	}
+
+	candidates := ordered
+	if !options.Wide && len(options.Defaults) > 0 {
+		candidates = nil
+		for _, field := range options.Defaults {
+			if present[field] {
+				candidates = append(candidates, field)
+			}
+		}
+	}
+
+	var columns []string
+	used := make(map[string]bool)
+	for _, field := range leadingColumns {
+		if present[field] {
+			columns = append(columns, field)
+			used[field] = true
+		}
+	}
+	for _, field := range candidates {
+		if used[field] || (!options.Wide && systemColumns[field]) {
+			continue
+		}
+		columns = append(columns, field)
+		used[field] = true
+	}
+	return columns
+}
+
+// TableCell formats a record field for a table cell. Expanded relations are
+// shown by their presentable field, created/updated as relative times, and
+// dotted columns (expand.location.name) are looked up in nested objects.
+func TableCell(record map[string]interface{}, column string, now time.Time) string {
+	if expanded, ok := expandedRelation(record, column); ok {
+		return expanded
+	}
+
+	value, ok := lookupPath(record, column)
+	if !ok || value == nil {
+		return ""
+	}
+
+	if timestampColumns[column] {
+		if text, ok := value.(string); ok {
+			if relative := RelativeTime(text, now); relative != "" {
+				return relative
+			}
+		}
+	}
+
+	return formatTableValue(value)
+}
+
+// RelativeTime formats a PocketBase or RFC 3339 timestamp relative to now
+// ("3h 5m ago"), or returns "" when it cannot be parsed
+func RelativeTime(value string, now time.Time) string {
+	var parsed time.Time
+	var err error
+	for _, layout := range []string{"2006-01-02 15:04:05.000Z", "2006-01-02 15:04:05Z", time.RFC3339Nano} {
+		if parsed, err = time.Parse(layout, value); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return ""
+	}
+
+	age := now.Sub(parsed)
+	if age < 0 {
+		return "in " + FormatDuration(int64(-age.Seconds()))
+	}
+	if age > 30*24*time.Hour {
+		return parsed.Local().Format("2006-01-02")
+	}
+	return FormatDuration(int64(age.Seconds())) + " ago"
+}
+
+// expandedRelation presents a relation field through its expanded record(s)
+func expandedRelation(record map[string]interface{}, column string) (string, bool) {
+	expand, ok := record["expand"].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	switch related := expand[column].(type) {
+	case map[string]interface{}:
+		return presentable(related), true
+	case []interface{}:
+		names := make([]string, 0, len(related))
+		for _, item := range related {
+			if relatedRecord, ok := item.(map[string]interface{}); ok {
+				names = append(names, presentable(relatedRecord))
+			}
+		}
+		return strings.Join(names, ", "), true
+	}
+	return "", false
+}
+
+// presentable returns the field that best names a record, falling back to its id
+func presentable(record map[string]interface{}) string {
+	for _, field := range presentableFields {
+		if value, ok := record[field].(string); ok && value != "" {
+			return value
+		}
+	}
+	return formatTableValue(record["id"])
+}
+
+// lookupPath returns the value at a dotted path in nested objects
+func lookupPath(record map[string]interface{}, path string) (interface{}, bool) {
+	if value, ok := record[path]; ok {
+		return value, true
+	}
+
+	var current interface{} = record
+	for _, part := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// formatTableValue formats a value on a single line: lists of scalars are
+// comma-separated, objects are compact JSON
+func formatTableValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.Join(strings.Fields(v), " ")
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if _, nested := item.(map[string]interface{}); nested {
+				return FormatCell(v)
+			}
+			parts = append(parts, formatTableValue(item))
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return FormatCell(v)
+	}
+}
+
+// fitColumns truncates the widest columns until the table fits in maxWidth
+func fitColumns(headers []string, rows [][]string, maxWidth int) {
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = utf8.RuneCountInString(header)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if width := utf8.RuneCountInString(cell); width > widths[i] {
+				widths[i] = width
+			}
+		}
+	}
+
+	// Borders and padding: "| " before each column plus the closing "|"
+	overhead := 3*len(headers) + 1
+	total := overhead
+	for _, width := range widths {
+		total += width
+	}
+
+	for total > maxWidth {
+		widest := 0
+		for i := range widths {
+			if widths[i] > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+
+	for i := range headers {
+		headers[i] = truncateCell(headers[i], widths[i])
+	}
+	for _, row := range rows {
+		for i := range row {
+			row[i] = truncateCell(row[i], widths[i])
+		}
+	}
+}
+
+// truncateCell shortens s to width runes, marking the cut with an ellipsis
+func truncateCell(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys(data map[string]interface{}) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTableColumns(t *testing.T) {
+	records := []map[string]interface{}{
+		{"code": "lab1", "name": "Lab", "id": "abc", "type": "site", "collectionName": "locations", "updated": "2025-01-01 00:00:00.000Z"},
+		{"id": "def", "capacity": 5.0, "active": true},
+	}
+
+	tests := []struct {
+		name    string
+		options TableOptions
+		want    []string
+	}{
+		{
+			name: "id and name first, others sorted",
+			want: []string{"id", "name", "active", "capacity", "code", "type", "updated"},
+		},
+		{
+			name:    "schema order first, then sorted",
+			options: TableOptions{FieldOrder: []string{"id", "type", "code", "name", "missing"}},
+			want:    []string{"id", "name", "type", "code", "active", "capacity", "updated"},
+		},
+		{
+			name:    "columns used as given",
+			options: TableOptions{Columns: []string{"code", "id", "expand.location.name"}, FieldOrder: []string{"id", "name"}},
+			want:    []string{"code", "id", "expand.location.name"},
+		},
+		{
+			name:    "defaults present in the records",
+			options: TableOptions{Defaults: []string{"email", "code", "type", "updated"}},
+			want:    []string{"id", "name", "code", "type", "updated"},
+		},
+		{
+			name:    "wide ignores defaults and shows system fields",
+			options: TableOptions{Defaults: []string{"code"}, Wide: true, FieldOrder: []string{"code"}},
+			want:    []string{"id", "name", "code", "active", "capacity", "collectionName", "type", "updated"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TableColumns(records, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TableColumns = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamTableColumns(t *testing.T) {
+	batch := []map[string]interface{}{{"id": "abc", "name": "Lab"}}
+
+	tests := []struct {
+		name    string
+		options TableOptions
+		want    []string
+	}{
+		{
+			name:    "schema fields missing from the first batch",
+			options: TableOptions{FieldOrder: []string{"id", "name", "code", "parent_id"}},
+			want:    []string{"id", "name", "code", "parent_id"},
+		},
+		{
+			name:    "defaults when the schema is unknown",
+			options: TableOptions{Defaults: []string{"code", "updated"}},
+			want:    []string{"id", "name", "code", "updated"},
+		},
+		{
+			name: "first batch only",
+			want: []string{"id", "name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StreamTableColumns(batch, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StreamTableColumns = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFitColumns(t *testing.T) {
+	tests := []struct {
+		name        string
+		headers     []string
+		rows        [][]string
+		maxWidth    int
+		wantHeaders []string
+		wantRows    [][]string
+	}{
+		{
+			name:        "fits already",
+			headers:     []string{"ID", "NAME"},
+			rows:        [][]string{{"abc", "Lab"}},
+			maxWidth:    80,
+			wantHeaders: []string{"ID", "NAME"},
+			wantRows:    [][]string{{"abc", "Lab"}},
+		},
+		{
+			name:        "widest column shrinks first",
+			headers:     []string{"ID", "DESCRIPTION"},
+			rows:        [][]string{{"abc", "A long description of the lab"}},
+			maxWidth:    25,
+			wantHeaders: []string{"ID", "DESCRIPTION"},
+			wantRows:    [][]string{{"abc", "A long descrip…"}},
+		},
+		{
+			name:        "columns stop at the minimum width",
+			headers:     []string{"IDENTIFIER", "DESCRIPTION"},
+			rows:        [][]string{{"abcdefghij", "description"}},
+			maxWidth:    10,
+			wantHeaders: []string{"IDENT…", "DESCR…"},
+			wantRows:    [][]string{{"abcde…", "descr…"}},
+		},
+		{
+			name:        "multibyte text",
+			headers:     []string{"NAME"},
+			rows:        [][]string{{"Überraschungsei"}},
+			maxWidth:    12,
+			wantHeaders: []string{"NAME"},
+			wantRows:    [][]string{{"Überras…"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fitColumns(tt.headers, tt.rows, tt.maxWidth)
+			if !reflect.DeepEqual(tt.headers, tt.wantHeaders) {
+				t.Errorf("headers = %q, want %q", tt.headers, tt.wantHeaders)
+			}
+			if !reflect.DeepEqual(tt.rows, tt.wantRows) {
+				t.Errorf("rows = %q, want %q", tt.rows, tt.wantRows)
+			}
+		})
+	}
+}
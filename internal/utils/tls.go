@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// tlsVersions maps the accepted minimum TLS version names to crypto/tls versions
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSVersion parses a minimum TLS version such as "1.3" (empty means 1.2)
+func TLSVersion(name string) (uint16, error) {
+	if name == "" {
+		return tls.VersionTLS12, nil
+	}
+
+	version, ok := tlsVersions[strings.TrimPrefix(name, "v")]
+	if !ok {
+		names := make([]string, 0, len(tlsVersions))
+		for key := range tlsVersions {
+			names = append(names, key)
+		}
+		sort.Strings(names)
+		return 0, fmt.Errorf("invalid TLS version '%s'. Valid versions: %s", name, strings.Join(names, ", "))
+	}
+
+	return version, nil
+}
+
+// CertificateExpiry returns the earliest expiry of the certificates in a PEM file
+func CertificateExpiry(path string) (time.Time, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read certificate file: %w", err)
+	}
+
+	var earliest time.Time
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse certificate in %s: %w", path, err)
+		}
+		if earliest.IsZero() || cert.NotAfter.Before(earliest) {
+			earliest = cert.NotAfter
+		}
+	}
+
+	if earliest.IsZero() {
+		return time.Time{}, fmt.Errorf("no certificates found in %s", path)
+	}
+
+	return earliest, nil
+}
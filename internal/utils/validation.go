@@ -54,6 +54,7 @@ func ValidateNATSAuthMethod(method string) error {
 		config.NATSAuthUserPass,
 		config.NATSAuthToken,
 		config.NATSAuthCreds,
+		config.NATSAuthNKey,
 	}
 
 	for _, valid := range validMethods {
@@ -72,6 +73,9 @@ func ValidateOutputFormat(format string) error {
 		config.OutputFormatJSON,
 		config.OutputFormatYAML,
 		config.OutputFormatTable,
+		config.OutputFormatNDJSON,
+		config.OutputFormatCSV,
+		config.OutputFormatTSV,
 	}
 
 	format = strings.ToLower(format)